@@ -11,7 +11,6 @@ import (
 // UserUseCases defines the interface for user use cases
 type UserUseCases interface {
 	GetUser(ctx context.Context, id uuid.UUID) (*entities.User, error)
-	GetUsers(ctx context.Context) ([]*entities.User, error)
 	UpdateUser(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
 	DeleteUser(ctx context.Context, id uuid.UUID) error
 }
@@ -39,15 +38,6 @@ func (u *UserUseCasesImpl) GetUser(ctx context.Context, id uuid.UUID) (*entities
 	return user, nil
 }
 
-// GetUsers retrieves all users
-func (u *UserUseCasesImpl) GetUsers(ctx context.Context) ([]*entities.User, error) {
-	users, err := u.userRepo.FindAll(ctx)
-	if err != nil {
-		return nil, err
-	}
-	return users, nil
-}
-
 // UpdateUser updates a user
 func (u *UserUseCasesImpl) UpdateUser(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
 	// Implementation would go here
@@ -29,6 +29,7 @@ type Config struct {
 	RateLimitEnabled        bool
 	KeyRotationInterval     time.Duration
 	MaxEmailsPerDay         int
+	MaxPrekeyClaimsPerDay   int
 	HardDeleteRetentionPeriod time.Duration
 	CloudinaryURL           string
 }
@@ -59,6 +60,7 @@ func LoadConfig() *Config {
 		RateLimitEnabled:          getEnvAsBool("RATE_LIMIT_ENABLED", false),
 		KeyRotationInterval:       getEnvAsDuration("KEY_ROTATION_INTERVAL", 24*time.Hour),
 		MaxEmailsPerDay:           getEnvAsInt("MAX_EMAILS_PER_DAY", 2),
+		MaxPrekeyClaimsPerDay:     getEnvAsInt("MAX_PREKEY_CLAIMS_PER_DAY", 10),
 		HardDeleteRetentionPeriod: getEnvAsDuration("HARD_DELETE_RETENTION_PERIOD", 60*24*time.Hour),
 		CloudinaryURL:             getEnv("CLOUDINARY_URL", ""),
 	}
@@ -0,0 +1,59 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ContactRequestStatusPending   = "pending"
+	ContactRequestStatusAccepted  = "accepted"
+	ContactRequestStatusDeclined  = "declined"
+	ContactRequestStatusCancelled = "cancelled"
+)
+
+// ContactRequest is an invitation from one user to become another user's contact
+type ContactRequest struct {
+	ID          uuid.UUID  `json:"id"`
+	SenderID    uuid.UUID  `json:"sender_id"`
+	RecipientID uuid.UUID  `json:"recipient_id"`
+	Status      string     `json:"status"`
+	CreatedAt   time.Time  `json:"created_at"`
+	RespondedAt *time.Time `json:"responded_at,omitempty"`
+	// Sender and Recipient are the two accounts, when they were loaded along with the request
+	Sender    *User `json:"-"`
+	Recipient *User `json:"-"`
+}
+
+// Contact is one side of a mutual contact relationship; each relationship is stored once per user
+type Contact struct {
+	UserID    uuid.UUID `json:"user_id"`
+	ContactID uuid.UUID `json:"contact_id"`
+	CreatedAt time.Time `json:"created_at"`
+	// Contact is the contact's account, when it was loaded along with the relationship
+	Contact *User `json:"-"`
+}
+
+// NewContactRequest creates a new pending contact request
+func NewContactRequest(senderID, recipientID uuid.UUID) *ContactRequest {
+	return &ContactRequest{
+		ID:          uuid.New(),
+		SenderID:    senderID,
+		RecipientID: recipientID,
+		Status:      ContactRequestStatusPending,
+		CreatedAt:   time.Now(),
+	}
+}
+
+// IsPending reports whether the request still awaits an answer
+func (r *ContactRequest) IsPending() bool {
+	return r.Status == ContactRequestStatusPending
+}
+
+// Close records the final status of the request
+func (r *ContactRequest) Close(status string) {
+	now := time.Now()
+	r.Status = status
+	r.RespondedAt = &now
+}
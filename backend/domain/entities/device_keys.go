@@ -0,0 +1,61 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DeviceKeys holds the public key material a client device publishes for end-to-end encryption.
+// Private keys never leave the device; every key here is base64-encoded public data.
+type DeviceKeys struct {
+	UserID                uuid.UUID `json:"user_id"`
+	DeviceID              int       `json:"device_id"`
+	IdentityKey           string    `json:"identity_key"`
+	SignedPrekeyID        int       `json:"signed_prekey_id"`
+	SignedPrekey          string    `json:"signed_prekey"`
+	SignedPrekeySignature string    `json:"signed_prekey_signature"`
+	CreatedAt             time.Time `json:"created_at"`
+	UpdatedAt             time.Time `json:"updated_at"`
+}
+
+// OneTimePrekey is a single-use public prekey that is consumed when another user claims a bundle.
+type OneTimePrekey struct {
+	UserID    uuid.UUID `json:"user_id"`
+	DeviceID  int       `json:"device_id"`
+	KeyID     int       `json:"key_id"`
+	PublicKey string    `json:"public_key"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// PrekeyBundle is the key material a sender needs to open a session with a device.
+type PrekeyBundle struct {
+	Device        *DeviceKeys    `json:"device"`
+	OneTimePrekey *OneTimePrekey `json:"one_time_prekey,omitempty"`
+}
+
+// NewDeviceKeys creates a new device key record
+func NewDeviceKeys(userID uuid.UUID, deviceID int, identityKey string, signedPrekeyID int, signedPrekey, signature string) *DeviceKeys {
+	now := time.Now()
+	return &DeviceKeys{
+		UserID:                userID,
+		DeviceID:              deviceID,
+		IdentityKey:           identityKey,
+		SignedPrekeyID:        signedPrekeyID,
+		SignedPrekey:          signedPrekey,
+		SignedPrekeySignature: signature,
+		CreatedAt:             now,
+		UpdatedAt:             now,
+	}
+}
+
+// NewOneTimePrekey creates a new one-time prekey for a device
+func NewOneTimePrekey(userID uuid.UUID, deviceID, keyID int, publicKey string) *OneTimePrekey {
+	return &OneTimePrekey{
+		UserID:    userID,
+		DeviceID:  deviceID,
+		KeyID:     keyID,
+		PublicKey: publicKey,
+		CreatedAt: time.Now(),
+	}
+}
@@ -0,0 +1,26 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// HandleRedirect keeps a released handle pointing at its previous owner for a grace period
+type HandleRedirect struct {
+	Handle    string    `json:"handle"`
+	UserID    uuid.UUID `json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// NewHandleRedirect creates a redirect from an old handle that lasts for the given duration
+func NewHandleRedirect(handle string, userID uuid.UUID, gracePeriod time.Duration) *HandleRedirect {
+	now := time.Now()
+	return &HandleRedirect{
+		Handle:    handle,
+		UserID:    userID,
+		CreatedAt: now,
+		ExpiresAt: now.Add(gracePeriod),
+	}
+}
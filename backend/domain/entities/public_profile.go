@@ -0,0 +1,43 @@
+package entities
+
+import "github.com/google/uuid"
+
+// PublicProfile is the part of a user that other users may see. It never carries the email.
+type PublicProfile struct {
+	ID         uuid.UUID `json:"id"`
+	Name       string    `json:"name"`
+	Handle     *string   `json:"handle,omitempty"`
+	AvatarURL  *string   `json:"avatar_url,omitempty"`
+	Bio        *string   `json:"bio,omitempty"`
+	StatusText *string   `json:"status_text,omitempty"`
+	Pronouns   *string   `json:"pronouns,omitempty"`
+	Links      []string  `json:"links"`
+}
+
+// NewPublicProfile projects the public part of a user
+func NewPublicProfile(user *User) *PublicProfile {
+	return &PublicProfile{
+		ID:         user.ID,
+		Name:       user.Name,
+		Handle:     user.Handle,
+		AvatarURL:  user.AvatarURL,
+		Bio:        user.Bio,
+		StatusText: user.StatusText,
+		Pronouns:   user.Pronouns,
+		Links:      user.Links,
+	}
+}
+
+// UserSearchResult is a user matched by a search, with the ranking used to order and page results
+type UserSearchResult struct {
+	Profile     *PublicProfile
+	PrefixMatch bool
+	Score       float32
+}
+
+// UserSearchCursor is the position after which a search page starts
+type UserSearchCursor struct {
+	PrefixMatch bool
+	Score       float32
+	UserID      uuid.UUID
+}
@@ -0,0 +1,89 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ReportTargetUser = "user"
+
+	ReportStatusOpen     = "open"
+	ReportStatusClaimed  = "claimed"
+	ReportStatusResolved = "resolved"
+
+	ReportCategorySpam                 = "spam"
+	ReportCategoryHarassment           = "harassment"
+	ReportCategoryHateSpeech           = "hate_speech"
+	ReportCategoryImpersonation        = "impersonation"
+	ReportCategoryInappropriateContent = "inappropriate_content"
+	ReportCategoryOther                = "other"
+
+	ModerationActionDismiss = "dismiss"
+	ModerationActionWarn    = "warn"
+	ModerationActionSuspend = "suspend"
+)
+
+// Report represents an abuse report filed by a user and worked by moderators
+type Report struct {
+	ID             uuid.UUID      `json:"id"`
+	ReporterID     uuid.UUID      `json:"reporter_id"`
+	TargetType     string         `json:"target_type"`
+	TargetUserID   uuid.UUID      `json:"target_user_id"`
+	Category       string         `json:"category"`
+	Details        *string        `json:"details,omitempty"`
+	Snapshot       map[string]any `json:"snapshot"`
+	Status         string         `json:"status"`
+	ClaimedBy      *uuid.UUID     `json:"claimed_by,omitempty"`
+	ClaimedAt      *time.Time     `json:"claimed_at,omitempty"`
+	ResolvedBy     *uuid.UUID     `json:"resolved_by,omitempty"`
+	ResolvedAt     *time.Time     `json:"resolved_at,omitempty"`
+	Action         *string        `json:"action,omitempty"`
+	ResolutionNote *string        `json:"resolution_note,omitempty"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+}
+
+// ReportFilter narrows down the moderation queue
+type ReportFilter struct {
+	Status    *string
+	Category  *string
+	ClaimedBy *uuid.UUID
+}
+
+// NewUserReport creates a new report against a user, snapshotting the profile as it was when reported
+func NewUserReport(reporterID uuid.UUID, target *User, category string, details *string) *Report {
+	now := time.Now()
+	return &Report{
+		ID:           uuid.New(),
+		ReporterID:   reporterID,
+		TargetType:   ReportTargetUser,
+		TargetUserID: target.ID,
+		Category:     category,
+		Details:      details,
+		Snapshot: map[string]any{
+			"name":        target.Name,
+			"handle":      target.Handle,
+			"avatar_url":  target.AvatarURL,
+			"bio":         target.Bio,
+			"status_text": target.StatusText,
+			"pronouns":    target.Pronouns,
+			"links":       target.Links,
+		},
+		Status:    ReportStatusOpen,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// Resolve closes the report with the moderator's decision
+func (r *Report) Resolve(moderatorID uuid.UUID, action string, note *string) {
+	now := time.Now()
+	r.Status = ReportStatusResolved
+	r.ResolvedBy = &moderatorID
+	r.ResolvedAt = &now
+	r.Action = &action
+	r.ResolutionNote = note
+	r.UpdatedAt = now
+}
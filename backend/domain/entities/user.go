@@ -22,8 +22,29 @@ type User struct {
 	LastLoginAt       *time.Time `json:"last_login_at,omitempty"`
 	IsDeleted         bool       `json:"is_deleted"`
 	Gender            *string    `json:"gender,omitempty"`
+	Role              string     `json:"role"`
+	SuspendedUntil    *time.Time `json:"suspended_until,omitempty"`
+	DMPrivacy         string     `json:"dm_privacy"`
+	Discoverable      bool       `json:"discoverable"`
+	Handle            *string    `json:"handle,omitempty"`
+	HandleChangedAt   *time.Time `json:"handle_changed_at,omitempty"`
+	Bio               *string    `json:"bio,omitempty"`
+	StatusText        *string    `json:"status_text,omitempty"`
+	Pronouns          *string    `json:"pronouns,omitempty"`
+	Links             []string   `json:"links"`
 }
 
+const (
+	UserRoleUser      = "user"
+	UserRoleModerator = "moderator"
+)
+
+// Who may start a direct conversation with a user
+const (
+	DMPrivacyEveryone = "everyone"
+	DMPrivacyContacts = "contacts"
+)
+
 // NewUser creates a new user entity
 func NewUser(name, email, passwordHash, gender string) *User {
 	return &User{
@@ -36,6 +57,9 @@ func NewUser(name, email, passwordHash, gender string) *User {
 		IsEmailVerified: false,
 		IsDeleted:       false,
 		Gender:          &gender,
+		Role:            UserRoleUser,
+		DMPrivacy:       DMPrivacyEveryone,
+		Discoverable:    true,
 	}
 }
 
@@ -71,4 +95,28 @@ func (u *User) UpdateLastLogin() {
 func (u *User) VerifyEmail() {
 	u.IsEmailVerified = true
 	u.UpdatedAt = time.Now()
-}
\ No newline at end of file
+}
+
+// ChangeHandle sets a new handle and records when it was changed
+func (u *User) ChangeHandle(handle string) {
+	now := time.Now()
+	u.Handle = &handle
+	u.HandleChangedAt = &now
+	u.UpdatedAt = now
+}
+
+// IsModerator reports whether the user can work the moderation queue
+func (u *User) IsModerator() bool {
+	return u.Role == UserRoleModerator
+}
+
+// Suspend blocks the user from logging in until the given time
+func (u *User) Suspend(until time.Time) {
+	u.SuspendedUntil = &until
+	u.UpdatedAt = time.Now()
+}
+
+// IsSuspended reports whether the user is currently suspended
+func (u *User) IsSuspended() bool {
+	return u.SuspendedUntil != nil && time.Now().Before(*u.SuspendedUntil)
+}
@@ -0,0 +1,25 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserBlock records that one user blocked another
+type UserBlock struct {
+	BlockerID uuid.UUID `json:"blocker_id"`
+	BlockedID uuid.UUID `json:"blocked_id"`
+	CreatedAt time.Time `json:"created_at"`
+	// Blocked is the blocked account, when it was loaded along with the block
+	Blocked *User `json:"-"`
+}
+
+// NewUserBlock creates a new block record
+func NewUserBlock(blockerID, blockedID uuid.UUID) *UserBlock {
+	return &UserBlock{
+		BlockerID: blockerID,
+		BlockedID: blockedID,
+		CreatedAt: time.Now(),
+	}
+}
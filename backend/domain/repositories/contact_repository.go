@@ -0,0 +1,36 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jefersonprimer/chatear/backend/domain/entities"
+)
+
+// ContactRepository defines the interface for contact and contact request data operations
+type ContactRepository interface {
+	// CreateRequest returns ErrContactRequestExists if a pending request between the two users already exists.
+	CreateRequest(ctx context.Context, request *entities.ContactRequest) error
+	FindRequestByID(ctx context.Context, id uuid.UUID) (*entities.ContactRequest, error)
+	// FindPendingRequestBetween returns the pending request between two users in either direction, or nil.
+	FindPendingRequestBetween(ctx context.Context, userID, otherUserID uuid.UUID) (*entities.ContactRequest, error)
+	// HasRequestClosedSince reports whether a request from sender to recipient was cancelled or declined after since.
+	HasRequestClosedSince(ctx context.Context, senderID, recipientID uuid.UUID, since time.Time) (bool, error)
+	// FindPendingRequestsByRecipientID and FindPendingRequestsBySenderID return requests with Sender and Recipient
+	// set, leaving out requests involving a deleted account.
+	FindPendingRequestsByRecipientID(ctx context.Context, recipientID uuid.UUID) ([]*entities.ContactRequest, error)
+	FindPendingRequestsBySenderID(ctx context.Context, senderID uuid.UUID) ([]*entities.ContactRequest, error)
+	// UpdateRequest closes a pending request and returns ErrContactRequestClosed if it was no longer pending.
+	UpdateRequest(ctx context.Context, request *entities.ContactRequest) error
+	// AcceptRequest marks a pending request accepted and makes both users contacts of each other.
+	// It returns ErrContactRequestClosed if the request was no longer pending.
+	AcceptRequest(ctx context.Context, request *entities.ContactRequest) error
+	// CancelPendingRequestsBetween cancels any pending request between two users in either direction.
+	CancelPendingRequestsBetween(ctx context.Context, userID, otherUserID uuid.UUID) error
+	AreContacts(ctx context.Context, userID, otherUserID uuid.UUID) (bool, error)
+	// FindContactsByUserID returns contacts with Contact set, leaving out deleted accounts.
+	FindContactsByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.Contact, error)
+	// RemoveContact removes the relationship for both users.
+	RemoveContact(ctx context.Context, userID, otherUserID uuid.UUID) error
+}
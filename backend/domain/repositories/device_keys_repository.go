@@ -0,0 +1,22 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jefersonprimer/chatear/backend/domain/entities"
+)
+
+// DeviceKeysRepository defines the interface for the end-to-end encryption key directory.
+type DeviceKeysRepository interface {
+	// PublishDevice creates or replaces the keys of a device and adds its one-time prekeys in one transaction.
+	// With replacePrekeys, the device's existing prekeys are dropped first.
+	PublishDevice(ctx context.Context, device *entities.DeviceKeys, prekeys []*entities.OneTimePrekey, replacePrekeys bool) error
+	FindDevice(ctx context.Context, userID uuid.UUID, deviceID int) (*entities.DeviceKeys, error)
+	FindDevicesByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.DeviceKeys, error)
+	AddOneTimePrekeys(ctx context.Context, prekeys []*entities.OneTimePrekey) error
+	// ClaimOneTimePrekey atomically removes and returns one prekey, or nil when the device has none left,
+	// together with how many prekeys remain after the claim.
+	ClaimOneTimePrekey(ctx context.Context, userID uuid.UUID, deviceID int) (*entities.OneTimePrekey, int, error)
+	CountOneTimePrekeys(ctx context.Context, userID uuid.UUID, deviceID int) (int, error)
+}
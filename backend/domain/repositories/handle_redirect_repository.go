@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/jefersonprimer/chatear/backend/domain/entities"
+)
+
+// HandleRedirectRepository defines the interface for redirects from released handles
+type HandleRedirectRepository interface {
+	// Save stores a redirect, replacing any earlier redirect for the same handle.
+	Save(ctx context.Context, redirect *entities.HandleRedirect) error
+	// FindActive returns the unexpired redirect for a handle, ignoring case.
+	FindActive(ctx context.Context, handle string) (*entities.HandleRedirect, error)
+	Delete(ctx context.Context, handle string) error
+	// ChangeHandle stores the user's new handle, ends any redirect from it and, if redirect is not nil,
+	// saves the redirect from the released handle, all in one transaction.
+	// It returns ErrHandleTaken if another user holds the new handle.
+	ChangeHandle(ctx context.Context, user *entities.User, redirect *entities.HandleRedirect) error
+}
@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jefersonprimer/chatear/backend/domain/entities"
+)
+
+// ReportRepository defines the interface for abuse report data operations
+type ReportRepository interface {
+	Create(ctx context.Context, report *entities.Report) error
+	FindByID(ctx context.Context, id uuid.UUID) (*entities.Report, error)
+	// HasPendingReport reports whether the reporter already has an unresolved report against the target.
+	HasPendingReport(ctx context.Context, reporterID, targetUserID uuid.UUID) (bool, error)
+	List(ctx context.Context, filter entities.ReportFilter, limit, offset int) ([]*entities.Report, error)
+	// Claim assigns an open report to a moderator and returns false if it was no longer open.
+	Claim(ctx context.Context, id, moderatorID uuid.UUID) (bool, error)
+	// Resolve stores the resolution and returns false if the report was not claimed by the resolving moderator.
+	// If suspendUntil is set, the reported user is suspended and their sessions revoked in the same transaction.
+	Resolve(ctx context.Context, report *entities.Report, suspendUntil *time.Time) (bool, error)
+}
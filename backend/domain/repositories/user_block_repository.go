@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jefersonprimer/chatear/backend/domain/entities"
+)
+
+// UserBlockRepository defines the interface for user block data operations
+type UserBlockRepository interface {
+	Create(ctx context.Context, block *entities.UserBlock) error
+	Delete(ctx context.Context, blockerID, blockedID uuid.UUID) error
+	IsBlocked(ctx context.Context, blockerID, blockedID uuid.UUID) (bool, error)
+	// FindByBlockerID returns the blocks made by a user with Blocked set, leaving out deleted accounts.
+	FindByBlockerID(ctx context.Context, blockerID uuid.UUID) ([]*entities.UserBlock, error)
+}
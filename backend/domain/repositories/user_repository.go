@@ -13,8 +13,12 @@ type UserRepository interface {
 	Create(ctx context.Context, user *entities.User) error
 	FindByID(ctx context.Context, id uuid.UUID) (*entities.User, error)
 	FindByEmail(ctx context.Context, email string) (*entities.User, error)
-	FindAll(ctx context.Context) ([]*entities.User, error)
+	// FindByHandle looks up a user by their current handle, ignoring case.
+	FindByHandle(ctx context.Context, handle string) (*entities.User, error)
+	// Update writes back the user, except for the suspension and the handle, which have their own writes.
 	Update(ctx context.Context, user *entities.User) error
+	// UpdateLastLogin records a login without writing back the rest of the user.
+	UpdateLastLogin(ctx context.Context, id uuid.UUID, at time.Time) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	FindSoftDeletedBefore(ctx context.Context, t time.Time) ([]*entities.User, error)
 	HardDelete(ctx context.Context, id uuid.UUID) error
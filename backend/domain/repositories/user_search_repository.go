@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jefersonprimer/chatear/backend/domain/entities"
+)
+
+// UserSearchRepository defines the interface for finding users by name or handle
+type UserSearchRepository interface {
+	// Search returns discoverable users matching the query, best matches first, leaving out the viewer
+	// and anyone who blocked or was blocked by them.
+	Search(ctx context.Context, viewerID uuid.UUID, query string, limit int, after *entities.UserSearchCursor) ([]*entities.UserSearchResult, error)
+}
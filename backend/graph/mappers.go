@@ -1,10 +1,12 @@
 package graph
 
 import (
+	"strings"
 	"time"
 
 	"github.com/jefersonprimer/chatear/backend/domain/entities"
 	"github.com/jefersonprimer/chatear/backend/graph/model"
+	"github.com/jefersonprimer/chatear/backend/internal/user/application"
 )
 
 // timePtrToStringPtr converts a *time.Time to a *string, handling nil.
@@ -30,5 +32,190 @@ func toModelUser(user *entities.User) *model.User {
 		LastLoginAt:       timePtrToStringPtr(user.LastLoginAt),
 		IsDeleted:         user.IsDeleted,
 		Gender:            (*model.Gender)(user.Gender),
+		Handle:            user.Handle,
+		Bio:               user.Bio,
+		StatusText:        user.StatusText,
+		Pronouns:          user.Pronouns,
+		Links:             nonNilLinks(user.Links),
 	}
-}
\ No newline at end of file
+}
+
+// nonNilLinks returns an empty list for users loaded without their links.
+func nonNilLinks(links []string) []string {
+	if links == nil {
+		return []string{}
+	}
+	return links
+}
+
+func toModelSignedPrekey(device *entities.DeviceKeys) *model.SignedPrekey {
+	return &model.SignedPrekey{
+		KeyID:     device.SignedPrekeyID,
+		PublicKey: device.SignedPrekey,
+		Signature: device.SignedPrekeySignature,
+	}
+}
+
+func toModelDeviceKeys(device *entities.DeviceKeys) *model.DeviceKeys {
+	return &model.DeviceKeys{
+		UserID:       device.UserID.String(),
+		DeviceID:     device.DeviceID,
+		IdentityKey:  device.IdentityKey,
+		SignedPrekey: toModelSignedPrekey(device),
+		UpdatedAt:    device.UpdatedAt.String(),
+	}
+}
+
+func toModelPrekeyBundle(bundle *entities.PrekeyBundle) *model.PrekeyBundle {
+	modelBundle := &model.PrekeyBundle{
+		UserID:       bundle.Device.UserID.String(),
+		DeviceID:     bundle.Device.DeviceID,
+		IdentityKey:  bundle.Device.IdentityKey,
+		SignedPrekey: toModelSignedPrekey(bundle.Device),
+	}
+	if bundle.OneTimePrekey != nil {
+		modelBundle.OneTimePrekey = &model.OneTimePrekey{
+			KeyID:     bundle.OneTimePrekey.KeyID,
+			PublicKey: bundle.OneTimePrekey.PublicKey,
+		}
+	}
+	return modelBundle
+}
+
+func toOneTimePrekeyInputs(inputs []*model.OneTimePrekeyInput) []application.OneTimePrekeyInput {
+	prekeys := make([]application.OneTimePrekeyInput, 0, len(inputs))
+	for _, input := range inputs {
+		prekeys = append(prekeys, application.OneTimePrekeyInput{KeyID: input.KeyID, PublicKey: input.PublicKey})
+	}
+	return prekeys
+}
+
+// snapshotString reads a text field of a report snapshot, which holds *string values until it is stored
+// and plain strings once it is read back from JSON.
+func snapshotString(value any) *string {
+	switch value := value.(type) {
+	case string:
+		return &value
+	case *string:
+		return value
+	}
+	return nil
+}
+
+func toModelReport(report *entities.Report) *model.Report {
+	snapshot := &model.ReportedUserSnapshot{
+		Handle:     snapshotString(report.Snapshot["handle"]),
+		AvatarURL:  snapshotString(report.Snapshot["avatar_url"]),
+		Bio:        snapshotString(report.Snapshot["bio"]),
+		StatusText: snapshotString(report.Snapshot["status_text"]),
+		Pronouns:   snapshotString(report.Snapshot["pronouns"]),
+		Links:      []string{},
+	}
+	if name := snapshotString(report.Snapshot["name"]); name != nil {
+		snapshot.Name = *name
+	}
+	switch links := report.Snapshot["links"].(type) {
+	case []string:
+		snapshot.Links = links
+	case []any:
+		for _, link := range links {
+			if link, ok := link.(string); ok {
+				snapshot.Links = append(snapshot.Links, link)
+			}
+		}
+	}
+
+	modelReport := &model.Report{
+		ID:             report.ID.String(),
+		ReporterID:     report.ReporterID.String(),
+		TargetUserID:   report.TargetUserID.String(),
+		Category:       model.ReportCategory(strings.ToUpper(report.Category)),
+		Details:        report.Details,
+		ReportedUser:   snapshot,
+		Status:         model.ReportStatus(strings.ToUpper(report.Status)),
+		ResolutionNote: report.ResolutionNote,
+		CreatedAt:      report.CreatedAt.String(),
+		ResolvedAt:     timePtrToStringPtr(report.ResolvedAt),
+	}
+	if report.ClaimedBy != nil {
+		claimedBy := report.ClaimedBy.String()
+		modelReport.ClaimedBy = &claimedBy
+	}
+	if report.Action != nil {
+		action := model.ModerationAction(strings.ToUpper(*report.Action))
+		modelReport.Action = &action
+	}
+	return modelReport
+}
+
+func toModelBlockedUser(blockedUser *application.BlockedUser) *model.BlockedUser {
+	return &model.BlockedUser{
+		ID:        blockedUser.User.ID.String(),
+		Name:      blockedUser.User.Name,
+		AvatarURL: blockedUser.User.AvatarURL,
+		BlockedAt: blockedUser.BlockedAt.String(),
+	}
+}
+
+func toModelContactUser(user *entities.User) *model.ContactUser {
+	return &model.ContactUser{
+		ID:        user.ID.String(),
+		Name:      user.Name,
+		AvatarURL: user.AvatarURL,
+	}
+}
+
+func toModelContact(contact *application.ContactEntry) *model.Contact {
+	return &model.Contact{
+		User:  toModelContactUser(contact.User),
+		Since: contact.Since.String(),
+	}
+}
+
+func toModelContactRequest(entry *application.ContactRequestEntry) *model.ContactRequest {
+	return &model.ContactRequest{
+		ID:          entry.Request.ID.String(),
+		Sender:      toModelContactUser(entry.Sender),
+		Recipient:   toModelContactUser(entry.Recipient),
+		Status:      model.ContactRequestStatus(strings.ToUpper(entry.Request.Status)),
+		CreatedAt:   entry.Request.CreatedAt.String(),
+		RespondedAt: timePtrToStringPtr(entry.Request.RespondedAt),
+	}
+}
+
+func toModelPrivacySettings(user *entities.User) *model.PrivacySettings {
+	return &model.PrivacySettings{
+		DirectMessages: model.DirectMessagePrivacy(strings.ToUpper(user.DMPrivacy)),
+		Discoverable:   user.Discoverable,
+	}
+}
+
+func toModelPublicProfile(profile *entities.PublicProfile) *model.PublicProfile {
+	return &model.PublicProfile{
+		ID:         profile.ID.String(),
+		Name:       profile.Name,
+		Handle:     profile.Handle,
+		AvatarURL:  profile.AvatarURL,
+		Bio:        profile.Bio,
+		StatusText: profile.StatusText,
+		Pronouns:   profile.Pronouns,
+		Links:      nonNilLinks(profile.Links),
+	}
+}
+
+func toModelUserSearchConnection(page *application.UserSearchPage) *model.UserSearchConnection {
+	connection := &model.UserSearchConnection{
+		Edges:    make([]*model.UserSearchEdge, 0, len(page.Hits)),
+		PageInfo: &model.PageInfo{HasNextPage: page.HasNextPage},
+	}
+	for _, hit := range page.Hits {
+		connection.Edges = append(connection.Edges, &model.UserSearchEdge{
+			Cursor: hit.Cursor,
+			Node:   toModelPublicProfile(hit.Profile),
+		})
+	}
+	if len(page.Hits) > 0 {
+		connection.PageInfo.EndCursor = &page.Hits[len(page.Hits)-1].Cursor
+	}
+	return connection
+}
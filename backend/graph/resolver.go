@@ -21,7 +21,6 @@ type Resolver struct {
 	DeleteUser             *userApplication.DeleteUser
 	RecoverAccount         *userApplication.RecoverAccount
 	RefreshToken           *userApplication.RefreshToken
-	GetUsersUseCase        usecases.UserUseCases
 	TokenService           services.TokenService
 	OneTimeTokenService    services.OneTimeTokenService
 	EmailRateLimiter       notificationApplication.RateLimiter
@@ -29,5 +28,12 @@ type Resolver struct {
 	UserDeletionRepository repositories.UserDeletionRepository
 	UserRepository         repositories.UserRepository
 	AvatarUsecases         *usecases.AvatarUsecases
+	KeyDirectory           *userApplication.KeyDirectory
+	Moderation             *userApplication.Moderation
+	Blocking               *userApplication.Blocking
+	Contacts               *userApplication.Contacts
+	PrivacySettings        *userApplication.PrivacySettings
+	UserSearch             *userApplication.UserSearch
+	Profiles               *userApplication.Profiles
 }
 
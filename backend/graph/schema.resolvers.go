@@ -7,9 +7,11 @@ package graph
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/99designs/gqlgen/graphql"
 	"github.com/google/uuid"
+	"github.com/jefersonprimer/chatear/backend/domain/entities"
 	"github.com/jefersonprimer/chatear/backend/graph/model"
 	"github.com/jefersonprimer/chatear/backend/internal/user/application"
 	"github.com/jefersonprimer/chatear/backend/shared/auth"
@@ -55,9 +57,14 @@ func (r *mutationResolver) RegisterUser(ctx context.Context, input model.Registe
 // Login is the resolver for the login field.
 func (r *mutationResolver) Login(ctx context.Context, input model.LoginInput) (*model.AuthResponse, error) {
 	loginReq := application.LoginRequest{
-		Email:    input.Email,
 		Password: input.Password,
 	}
+	if input.Email != nil {
+		loginReq.Email = *input.Email
+	}
+	if input.Handle != nil {
+		loginReq.Handle = *input.Handle
+	}
 
 	loginOutput, err := r.Resolver.LoginUseCase.Execute(ctx, loginReq)
 	if err != nil {
@@ -185,24 +192,333 @@ func (r *mutationResolver) DeleteAvatar(ctx context.Context) (bool, error) {
 	return true, nil
 }
 
-// Register is the resolver for the register field.
-func (r *mutationResolver) Register(ctx context.Context, input model.RegisterUserInput) (*model.User, error) {
-	panic(fmt.Errorf("not implemented: Register - register"))
+// PublishDeviceKeys is the resolver for the publishDeviceKeys field.
+func (r *mutationResolver) PublishDeviceKeys(ctx context.Context, input model.PublishDeviceKeysInput) (*model.DeviceKeys, error) {
+	userID, err := auth.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	publishReq := application.PublishDeviceKeysRequest{
+		DeviceID:              input.DeviceID,
+		IdentityKey:           input.IdentityKey,
+		SignedPrekeyID:        input.SignedPrekey.KeyID,
+		SignedPrekey:          input.SignedPrekey.PublicKey,
+		SignedPrekeySignature: input.SignedPrekey.Signature,
+		OneTimePrekeys:        toOneTimePrekeyInputs(input.OneTimePrekeys),
+	}
+
+	device, err := r.Resolver.KeyDirectory.PublishDeviceKeys(ctx, userID, publishReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelDeviceKeys(device), nil
+}
+
+// UploadOneTimePrekeys is the resolver for the uploadOneTimePrekeys field.
+func (r *mutationResolver) UploadOneTimePrekeys(ctx context.Context, deviceID int, prekeys []*model.OneTimePrekeyInput) (int, error) {
+	userID, err := auth.GetUserIDFromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return r.Resolver.KeyDirectory.UploadOneTimePrekeys(ctx, userID, deviceID, toOneTimePrekeyInputs(prekeys))
+}
+
+// ClaimPrekeyBundle is the resolver for the claimPrekeyBundle field.
+func (r *mutationResolver) ClaimPrekeyBundle(ctx context.Context, userID string, deviceID int) (*model.PrekeyBundle, error) {
+	requesterID, err := auth.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	targetUserID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	bundle, err := r.Resolver.KeyDirectory.ClaimPrekeyBundle(ctx, requesterID, targetUserID, deviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelPrekeyBundle(bundle), nil
+}
+
+// ReportUser is the resolver for the reportUser field.
+func (r *mutationResolver) ReportUser(ctx context.Context, input model.ReportUserInput) (*model.Report, error) {
+	userID, err := auth.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	targetUserID, err := uuid.Parse(input.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	reportReq := application.ReportUserRequest{
+		TargetUserID: targetUserID,
+		Category:     strings.ToLower(input.Category.String()),
+		Details:      input.Details,
+	}
+
+	report, err := r.Resolver.Moderation.ReportUser(ctx, userID, reportReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelReport(report), nil
+}
+
+// ClaimReport is the resolver for the claimReport field.
+func (r *mutationResolver) ClaimReport(ctx context.Context, reportID string) (*model.Report, error) {
+	userID, err := auth.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.Parse(reportID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid report ID: %w", err)
+	}
+
+	report, err := r.Resolver.Moderation.ClaimReport(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelReport(report), nil
+}
+
+// ResolveReport is the resolver for the resolveReport field.
+func (r *mutationResolver) ResolveReport(ctx context.Context, input model.ResolveReportInput) (*model.Report, error) {
+	userID, err := auth.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reportID, err := uuid.Parse(input.ReportID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid report ID: %w", err)
+	}
+
+	resolveReq := application.ResolveReportRequest{
+		ReportID:    reportID,
+		Action:      strings.ToLower(input.Action.String()),
+		Note:        input.Note,
+		SuspendDays: input.SuspendDays,
+	}
+
+	report, err := r.Resolver.Moderation.ResolveReport(ctx, userID, resolveReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelReport(report), nil
 }
 
-// Users is the resolver for the users field.
-func (r *queryResolver) Users(ctx context.Context) ([]*model.User, error) {
-	users, err := r.Resolver.GetUsersUseCase.GetUsers(ctx)
+// BlockUser is the resolver for the blockUser field.
+func (r *mutationResolver) BlockUser(ctx context.Context, userID string) (bool, error) {
+	blockerID, err := auth.GetUserIDFromContext(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	blockedID, err := uuid.Parse(userID)
+	if err != nil {
+		return false, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	if err := r.Resolver.Blocking.BlockUser(ctx, blockerID, blockedID); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// UnblockUser is the resolver for the unblockUser field.
+func (r *mutationResolver) UnblockUser(ctx context.Context, userID string) (bool, error) {
+	blockerID, err := auth.GetUserIDFromContext(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	blockedID, err := uuid.Parse(userID)
+	if err != nil {
+		return false, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	if err := r.Resolver.Blocking.UnblockUser(ctx, blockerID, blockedID); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// SendContactRequest is the resolver for the sendContactRequest field.
+func (r *mutationResolver) SendContactRequest(ctx context.Context, userID string) (*model.ContactRequest, error) {
+	senderID, err := auth.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	recipientID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	request, err := r.Resolver.Contacts.SendContactRequest(ctx, senderID, recipientID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelContactRequest(request), nil
+}
+
+// AcceptContactRequest is the resolver for the acceptContactRequest field.
+func (r *mutationResolver) AcceptContactRequest(ctx context.Context, requestID string) (*model.ContactRequest, error) {
+	userID, err := auth.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.Parse(requestID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid contact request ID: %w", err)
+	}
+
+	request, err := r.Resolver.Contacts.AcceptContactRequest(ctx, userID, id)
 	if err != nil {
 		return nil, err
 	}
 
-	var modelUsers []*model.User
-	for _, user := range users {
-		modelUsers = append(modelUsers, toModelUser(user))
+	return toModelContactRequest(request), nil
+}
+
+// DeclineContactRequest is the resolver for the declineContactRequest field.
+func (r *mutationResolver) DeclineContactRequest(ctx context.Context, requestID string) (*model.ContactRequest, error) {
+	userID, err := auth.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	return modelUsers, nil
+	id, err := uuid.Parse(requestID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid contact request ID: %w", err)
+	}
+
+	request, err := r.Resolver.Contacts.DeclineContactRequest(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelContactRequest(request), nil
+}
+
+// CancelContactRequest is the resolver for the cancelContactRequest field.
+func (r *mutationResolver) CancelContactRequest(ctx context.Context, requestID string) (*model.ContactRequest, error) {
+	userID, err := auth.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.Parse(requestID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid contact request ID: %w", err)
+	}
+
+	request, err := r.Resolver.Contacts.CancelContactRequest(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelContactRequest(request), nil
+}
+
+// RemoveContact is the resolver for the removeContact field.
+func (r *mutationResolver) RemoveContact(ctx context.Context, userID string) (bool, error) {
+	currentUserID, err := auth.GetUserIDFromContext(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	contactID, err := uuid.Parse(userID)
+	if err != nil {
+		return false, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	if err := r.Resolver.Contacts.RemoveContact(ctx, currentUserID, contactID); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// UpdatePrivacySettings is the resolver for the updatePrivacySettings field.
+func (r *mutationResolver) UpdatePrivacySettings(ctx context.Context, input model.PrivacySettingsInput) (*model.PrivacySettings, error) {
+	userID, err := auth.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	privacyReq := application.UpdatePrivacySettingsRequest{
+		Discoverable: input.Discoverable,
+	}
+	if input.DirectMessages != nil {
+		dmPrivacy := strings.ToLower(input.DirectMessages.String())
+		privacyReq.DMPrivacy = &dmPrivacy
+	}
+
+	user, err := r.Resolver.PrivacySettings.UpdatePrivacySettings(ctx, userID, privacyReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelPrivacySettings(user), nil
+}
+
+// ChangeHandle is the resolver for the changeHandle field.
+func (r *mutationResolver) ChangeHandle(ctx context.Context, handle string) (*model.User, error) {
+	userID, err := auth.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := r.Resolver.Profiles.ChangeHandle(ctx, userID, handle)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelUser(user), nil
+}
+
+// UpdateProfile is the resolver for the updateProfile field.
+func (r *mutationResolver) UpdateProfile(ctx context.Context, input model.UpdateProfileInput) (*model.User, error) {
+	userID, err := auth.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	profileReq := application.UpdateProfileRequest{
+		Bio:        input.Bio,
+		StatusText: input.StatusText,
+		Pronouns:   input.Pronouns,
+		Links:      input.Links,
+	}
+
+	user, err := r.Resolver.Profiles.UpdateProfile(ctx, userID, profileReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelUser(user), nil
+}
+
+// Register is the resolver for the register field.
+func (r *mutationResolver) Register(ctx context.Context, input model.RegisterUserInput) (*model.User, error) {
+	panic(fmt.Errorf("not implemented: Register - register"))
 }
 
 // Me is the resolver for the me field.
@@ -220,6 +536,194 @@ func (r *queryResolver) Me(ctx context.Context) (*model.User, error) {
 	return toModelUser(user), nil
 }
 
+// UserDevices is the resolver for the userDevices field.
+func (r *queryResolver) UserDevices(ctx context.Context, userID string) ([]*model.DeviceKeys, error) {
+	requesterID, err := auth.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	targetUserID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	devices, err := r.Resolver.KeyDirectory.ListDevices(ctx, requesterID, targetUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	modelDevices := make([]*model.DeviceKeys, 0, len(devices))
+	for _, device := range devices {
+		modelDevices = append(modelDevices, toModelDeviceKeys(device))
+	}
+
+	return modelDevices, nil
+}
+
+// OneTimePrekeyCount is the resolver for the oneTimePrekeyCount field.
+func (r *queryResolver) OneTimePrekeyCount(ctx context.Context, deviceID int) (int, error) {
+	userID, err := auth.GetUserIDFromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return r.Resolver.KeyDirectory.CountOneTimePrekeys(ctx, userID, deviceID)
+}
+
+// ModerationQueue is the resolver for the moderationQueue field.
+func (r *queryResolver) ModerationQueue(ctx context.Context, filter *model.ReportFilter, limit *int, offset *int) ([]*model.Report, error) {
+	userID, err := auth.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reportFilter := entities.ReportFilter{}
+	if filter != nil {
+		if filter.Status != nil {
+			status := strings.ToLower(filter.Status.String())
+			reportFilter.Status = &status
+		}
+		if filter.Category != nil {
+			category := strings.ToLower(filter.Category.String())
+			reportFilter.Category = &category
+		}
+		if filter.ClaimedByMe != nil && *filter.ClaimedByMe {
+			reportFilter.ClaimedBy = &userID
+		}
+	}
+
+	var pageLimit, pageOffset int
+	if limit != nil {
+		pageLimit = *limit
+	}
+	if offset != nil {
+		pageOffset = *offset
+	}
+
+	reports, err := r.Resolver.Moderation.ListReports(ctx, userID, reportFilter, pageLimit, pageOffset)
+	if err != nil {
+		return nil, err
+	}
+
+	modelReports := make([]*model.Report, 0, len(reports))
+	for _, report := range reports {
+		modelReports = append(modelReports, toModelReport(report))
+	}
+
+	return modelReports, nil
+}
+
+// BlockedUsers is the resolver for the blockedUsers field.
+func (r *queryResolver) BlockedUsers(ctx context.Context) ([]*model.BlockedUser, error) {
+	userID, err := auth.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	blockedUsers, err := r.Resolver.Blocking.ListBlockedUsers(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	modelBlockedUsers := make([]*model.BlockedUser, 0, len(blockedUsers))
+	for _, blockedUser := range blockedUsers {
+		modelBlockedUsers = append(modelBlockedUsers, toModelBlockedUser(blockedUser))
+	}
+
+	return modelBlockedUsers, nil
+}
+
+// Contacts is the resolver for the contacts field.
+func (r *queryResolver) Contacts(ctx context.Context) ([]*model.Contact, error) {
+	userID, err := auth.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	contacts, err := r.Resolver.Contacts.ListContacts(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	modelContacts := make([]*model.Contact, 0, len(contacts))
+	for _, contact := range contacts {
+		modelContacts = append(modelContacts, toModelContact(contact))
+	}
+
+	return modelContacts, nil
+}
+
+// ContactRequests is the resolver for the contactRequests field.
+func (r *queryResolver) ContactRequests(ctx context.Context, direction model.ContactRequestDirection) ([]*model.ContactRequest, error) {
+	userID, err := auth.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	requests, err := r.Resolver.Contacts.ListContactRequests(ctx, userID, direction == model.ContactRequestDirectionOutgoing)
+	if err != nil {
+		return nil, err
+	}
+
+	modelRequests := make([]*model.ContactRequest, 0, len(requests))
+	for _, request := range requests {
+		modelRequests = append(modelRequests, toModelContactRequest(request))
+	}
+
+	return modelRequests, nil
+}
+
+// PrivacySettings is the resolver for the privacySettings field.
+func (r *queryResolver) PrivacySettings(ctx context.Context) (*model.PrivacySettings, error) {
+	userID, err := auth.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := r.Resolver.UserRepository.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelPrivacySettings(user), nil
+}
+
+// SearchUsers is the resolver for the searchUsers field.
+func (r *queryResolver) SearchUsers(ctx context.Context, query string, first *int, after *string) (*model.UserSearchConnection, error) {
+	userID, err := auth.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := 0
+	if first != nil {
+		limit = *first
+	}
+
+	page, err := r.Resolver.UserSearch.SearchUsers(ctx, userID, query, limit, after)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelUserSearchConnection(page), nil
+}
+
+// UserByHandle is the resolver for the userByHandle field.
+func (r *queryResolver) UserByHandle(ctx context.Context, handle string) (*model.PublicProfile, error) {
+	userID, err := auth.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := r.Resolver.Profiles.FindProfileByHandle(ctx, userID, handle)
+	if err != nil {
+		return nil, err
+	}
+
+	return toModelPublicProfile(profile), nil
+}
+
 // Mutation returns MutationResolver implementation.
 func (r *Resolver) Mutation() MutationResolver { return &mutationResolver{r} }
 
@@ -11,6 +11,12 @@ import (
 	notificationDomain "github.com/jefersonprimer/chatear/backend/internal/notification/domain"
 )
 
+// Notice kinds. Each kind has its own daily quota per recipient.
+const (
+	NoticeContact    = "contact"
+	NoticeModeration = "moderation"
+)
+
 // EmailService orchestrates sending different types of emails.
 type EmailService struct {
 	emailSender         notificationDomain.Sender
@@ -62,3 +68,41 @@ func (s *EmailService) SendMagicLinkEmail(ctx context.Context, recipient, userID
 
 	return nil
 }
+
+// SendNoticeEmail sends a plain informational email, such as the outcome of a moderation decision.
+func (s *EmailService) SendNoticeEmail(ctx context.Context, kind, recipient, name, subject string, paragraphs []string) error {
+	// Notices are counted apart from magic links, so other users cannot use up the recipient's quota for
+	// password resets, and apart from each other, so contact requests cannot hold back a moderation notice
+	limitKey := "notice:" + kind + ":" + recipient
+	isAllowed, err := s.emailRateLimiter.IsAllowed(ctx, limitKey)
+	if err != nil {
+		return fmt.Errorf("failed to check email rate limit: %w", err)
+	}
+	if !isAllowed {
+		return errors.ErrTooManyEmailAttempts
+	}
+
+	data := map[string]interface{}{
+		"Subject":    subject,
+		"Recipient":  recipient,
+		"Name":       name,
+		"Paragraphs": paragraphs,
+	}
+
+	emailSend := &notificationDomain.EmailSend{
+		Recipient:    recipient,
+		Subject:      subject,
+		TemplateName: "notice.html",
+		TemplateData: data,
+	}
+
+	if err := s.emailSender.Send(ctx, emailSend); err != nil {
+		return fmt.Errorf("failed to send notice email: %w", err)
+	}
+
+	if err := s.emailRateLimiter.Increment(ctx, limitKey); err != nil {
+		return fmt.Errorf("failed to increment email rate limit: %w", err)
+	}
+
+	return nil
+}
@@ -12,12 +12,19 @@ import (
 type RedisRateLimiter struct {
 	client *redis.Client
 	cfg    *config.Config
+	limit  int
 }
 
 func NewRedisRateLimiter(client *redis.Client, cfg *config.Config) *RedisRateLimiter {
+	return NewRedisRateLimiterWithLimit(client, cfg, cfg.MaxEmailsPerDay)
+}
+
+// NewRedisRateLimiterWithLimit creates a RedisRateLimiter that allows limit actions per key in a 24 hour window.
+func NewRedisRateLimiterWithLimit(client *redis.Client, cfg *config.Config, limit int) *RedisRateLimiter {
 	return &RedisRateLimiter{
 		client: client,
 		cfg:    cfg,
+		limit:  limit,
 	}
 }
 
@@ -69,6 +76,6 @@ func (r *RedisRateLimiter) IsAllowed(ctx context.Context, key string) (bool, err
 		return false, err
 	}
 
-	return count < r.cfg.MaxEmailsPerDay, nil
+	return count < r.limit, nil
 }
 
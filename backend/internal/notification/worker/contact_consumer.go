@@ -0,0 +1,41 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/jefersonprimer/chatear/backend/internal/notification/application"
+	"github.com/jefersonprimer/chatear/backend/shared/events"
+	"github.com/nats-io/nats.go"
+)
+
+// ContactConsumer consumes contact events and notifies the users involved.
+type ContactConsumer struct {
+	emailService *application.EmailService
+}
+
+// NewContactConsumer creates a new ContactConsumer.
+func NewContactConsumer(emailService *application.EmailService) *ContactConsumer {
+	return &ContactConsumer{
+		emailService: emailService,
+	}
+}
+
+// ConsumeContactRequestReceived tells a user that someone wants to add them as a contact.
+func (c *ContactConsumer) ConsumeContactRequestReceived(ctx context.Context, msg *nats.Msg) {
+	var event events.ContactRequestReceivedEvent
+	if err := json.Unmarshal(msg.Data, &event); err != nil {
+		log.Printf("Error unmarshalling contact request received event: %v", err)
+		return
+	}
+
+	paragraphs := []string{
+		fmt.Sprintf("%s wants to add you as a contact.", event.SenderName),
+		"Open your contacts page to accept or decline the request.",
+	}
+	if err := c.emailService.SendNoticeEmail(ctx, application.NoticeContact, event.Email, event.Name, "New contact request", paragraphs); err != nil {
+		log.Printf("Error sending contact request email for request %s: %v", event.RequestID, err)
+	}
+}
@@ -0,0 +1,80 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/jefersonprimer/chatear/backend/internal/notification/application"
+	"github.com/jefersonprimer/chatear/backend/shared/events"
+	"github.com/nats-io/nats.go"
+)
+
+// ModerationConsumer consumes moderation events and emails the people involved.
+type ModerationConsumer struct {
+	emailService *application.EmailService
+}
+
+// NewModerationConsumer creates a new ModerationConsumer.
+func NewModerationConsumer(emailService *application.EmailService) *ModerationConsumer {
+	return &ModerationConsumer{
+		emailService: emailService,
+	}
+}
+
+// ConsumeReportResolved tells the reporter that their report was reviewed.
+func (c *ModerationConsumer) ConsumeReportResolved(ctx context.Context, msg *nats.Msg) {
+	var event events.ReportResolvedEvent
+	if err := json.Unmarshal(msg.Data, &event); err != nil {
+		log.Printf("Error unmarshalling report resolved event: %v", err)
+		return
+	}
+
+	outcome := "Our moderators took action based on your report."
+	if event.Action == "dismiss" {
+		outcome = "Our moderators did not find a violation of our terms of use this time."
+	}
+
+	paragraphs := []string{"Thank you for your report. It has been reviewed by our moderation team.", outcome}
+	if err := c.emailService.SendNoticeEmail(ctx, application.NoticeModeration, event.Email, event.Name, "Your report has been reviewed", paragraphs); err != nil {
+		log.Printf("Error sending report resolution email for report %s: %v", event.ReportID, err)
+	}
+}
+
+// ConsumeUserWarned sends a warning to a reported user.
+func (c *ModerationConsumer) ConsumeUserWarned(ctx context.Context, msg *nats.Msg) {
+	var event events.UserWarnedEvent
+	if err := json.Unmarshal(msg.Data, &event); err != nil {
+		log.Printf("Error unmarshalling user warned event: %v", err)
+		return
+	}
+
+	paragraphs := []string{fmt.Sprintf("Your account was reported for %s and our moderators found that it broke our terms of use.", event.Category)}
+	if event.Note != "" {
+		paragraphs = append(paragraphs, event.Note)
+	}
+	paragraphs = append(paragraphs, "Further violations may lead to your account being suspended.")
+
+	if err := c.emailService.SendNoticeEmail(ctx, application.NoticeModeration, event.Email, event.Name, "Warning about your account", paragraphs); err != nil {
+		log.Printf("Error sending warning email for user %s: %v", event.UserID, err)
+	}
+}
+
+// ConsumeUserSuspended tells a reported user that their account was suspended.
+func (c *ModerationConsumer) ConsumeUserSuspended(ctx context.Context, msg *nats.Msg) {
+	var event events.UserSuspendedEvent
+	if err := json.Unmarshal(msg.Data, &event); err != nil {
+		log.Printf("Error unmarshalling user suspended event: %v", err)
+		return
+	}
+
+	paragraphs := []string{fmt.Sprintf("Your account has been suspended until %s for breaking our terms of use.", event.Until.Format("2006-01-02 15:04 MST"))}
+	if event.Note != "" {
+		paragraphs = append(paragraphs, event.Note)
+	}
+
+	if err := c.emailService.SendNoticeEmail(ctx, application.NoticeModeration, event.Email, event.Name, "Your account has been suspended", paragraphs); err != nil {
+		log.Printf("Error sending suspension email for user %s: %v", event.UserID, err)
+	}
+}
@@ -0,0 +1,102 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/jefersonprimer/chatear/backend/domain/entities"
+	"github.com/jefersonprimer/chatear/backend/domain/repositories"
+	"github.com/jefersonprimer/chatear/backend/shared/errors"
+)
+
+// BlockedUser is an entry of a user's block list.
+type BlockedUser struct {
+	User      *entities.User
+	BlockedAt time.Time
+}
+
+// Blocking is the use case for blocking and unblocking other users.
+type Blocking struct {
+	UserBlockRepository repositories.UserBlockRepository
+	ContactRepository   repositories.ContactRepository
+	UserRepository      repositories.UserRepository
+}
+
+// NewBlocking creates a new Blocking use case.
+func NewBlocking(userBlockRepo repositories.UserBlockRepository, contactRepo repositories.ContactRepository, userRepo repositories.UserRepository) *Blocking {
+	return &Blocking{
+		UserBlockRepository: userBlockRepo,
+		ContactRepository:   contactRepo,
+		UserRepository:      userRepo,
+	}
+}
+
+// BlockUser blocks another user, ending any contact relationship or pending contact request
+// between them. Blocking someone who is already blocked is a no-op.
+func (uc *Blocking) BlockUser(ctx context.Context, blockerID, blockedID uuid.UUID) error {
+	if blockerID == blockedID {
+		return errors.ErrCannotBlockSelf
+	}
+
+	blocked, err := uc.UserRepository.FindByID(ctx, blockedID)
+	if err == pgx.ErrNoRows || (err == nil && blocked.IsDeleted) {
+		return errors.ErrUserNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := uc.UserBlockRepository.Create(ctx, entities.NewUserBlock(blockerID, blocked.ID)); err != nil {
+		return fmt.Errorf("failed to block user: %w", err)
+	}
+	if err := uc.ContactRepository.RemoveContact(ctx, blockerID, blocked.ID); err != nil {
+		return fmt.Errorf("failed to remove blocked contact: %w", err)
+	}
+	if err := uc.ContactRepository.CancelPendingRequestsBetween(ctx, blockerID, blocked.ID); err != nil {
+		return fmt.Errorf("failed to cancel contact requests with blocked user: %w", err)
+	}
+	return nil
+}
+
+// UnblockUser removes a block. Unblocking someone who is not blocked is a no-op.
+func (uc *Blocking) UnblockUser(ctx context.Context, blockerID, blockedID uuid.UUID) error {
+	if err := uc.UserBlockRepository.Delete(ctx, blockerID, blockedID); err != nil {
+		return fmt.Errorf("failed to unblock user: %w", err)
+	}
+	return nil
+}
+
+// ListBlockedUsers returns the users blocked by blockerID, most recently blocked first.
+// Accounts that were deleted since are left out.
+func (uc *Blocking) ListBlockedUsers(ctx context.Context, blockerID uuid.UUID) ([]*BlockedUser, error) {
+	blocks, err := uc.UserBlockRepository.FindByBlockerID(ctx, blockerID)
+	if err != nil {
+		return nil, err
+	}
+
+	blockedUsers := make([]*BlockedUser, 0, len(blocks))
+	for _, block := range blocks {
+		blockedUsers = append(blockedUsers, &BlockedUser{User: block.Blocked, BlockedAt: block.CreatedAt})
+	}
+	return blockedUsers, nil
+}
+
+// ensureNotBlocked fails with ErrNotFound when ownerID has blocked requesterID, so a blocked
+// user cannot tell a block apart from a missing resource.
+func ensureNotBlocked(ctx context.Context, userBlockRepo repositories.UserBlockRepository, ownerID, requesterID uuid.UUID) error {
+	if ownerID == requesterID {
+		return nil
+	}
+	blocked, err := userBlockRepo.IsBlocked(ctx, ownerID, requesterID)
+	if err != nil {
+		return fmt.Errorf("failed to check block list: %w", err)
+	}
+	if blocked {
+		return errors.ErrNotFound
+	}
+	return nil
+}
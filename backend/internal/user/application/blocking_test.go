@@ -0,0 +1,97 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jefersonprimer/chatear/backend/domain/entities"
+	"github.com/jefersonprimer/chatear/backend/shared/errors"
+)
+
+type blockKey struct {
+	blockerID uuid.UUID
+	blockedID uuid.UUID
+}
+
+// MockUserBlockRepository is an in-memory implementation of repositories.UserBlockRepository
+type MockUserBlockRepository struct {
+	blocks map[blockKey]*entities.UserBlock
+	// users, if set, is where FindByBlockerID loads the blocked accounts from
+	users *MockUserRepository
+}
+
+func NewMockUserBlockRepository() *MockUserBlockRepository {
+	return &MockUserBlockRepository{blocks: map[blockKey]*entities.UserBlock{}}
+}
+
+func (m *MockUserBlockRepository) Create(ctx context.Context, block *entities.UserBlock) error {
+	key := blockKey{block.BlockerID, block.BlockedID}
+	if _, ok := m.blocks[key]; !ok {
+		m.blocks[key] = block
+	}
+	return nil
+}
+
+func (m *MockUserBlockRepository) Delete(ctx context.Context, blockerID, blockedID uuid.UUID) error {
+	delete(m.blocks, blockKey{blockerID, blockedID})
+	return nil
+}
+
+func (m *MockUserBlockRepository) IsBlocked(ctx context.Context, blockerID, blockedID uuid.UUID) (bool, error) {
+	_, ok := m.blocks[blockKey{blockerID, blockedID}]
+	return ok, nil
+}
+
+func (m *MockUserBlockRepository) FindByBlockerID(ctx context.Context, blockerID uuid.UUID) ([]*entities.UserBlock, error) {
+	var blocks []*entities.UserBlock
+	for key, block := range m.blocks {
+		if key.blockerID != blockerID || m.users == nil {
+			continue
+		}
+		if user, ok := m.users.users[key.blockedID]; ok && !user.IsDeleted {
+			block.Blocked = user
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks, nil
+}
+
+func TestBlocking_BlockAndUnblock(t *testing.T) {
+	ctx := context.Background()
+	blocker := entities.NewUser("Blocker", "blocker@example.com", "hash", "MALE")
+	blocked := entities.NewUser("Blocked", "blocked@example.com", "hash", "FEMALE")
+	blocks := NewMockUserBlockRepository()
+	contacts := NewMockContactRepository()
+	users := NewMockUserRepository(blocker, blocked)
+	blocks.users = users
+	uc := NewBlocking(blocks, contacts, users)
+	contacts.addContact(blocker.ID, blocked.ID)
+	contacts.addContact(blocked.ID, blocker.ID)
+
+	assert.ErrorIs(t, uc.BlockUser(ctx, blocker.ID, blocker.ID), errors.ErrCannotBlockSelf)
+	assert.ErrorIs(t, uc.BlockUser(ctx, blocker.ID, uuid.New()), errors.ErrUserNotFound)
+
+	require.NoError(t, uc.BlockUser(ctx, blocker.ID, blocked.ID))
+	require.NoError(t, uc.BlockUser(ctx, blocker.ID, blocked.ID))
+	areContacts, err := contacts.AreContacts(ctx, blocked.ID, blocker.ID)
+	require.NoError(t, err)
+	assert.False(t, areContacts)
+
+	list, err := uc.ListBlockedUsers(ctx, blocker.ID)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, blocked.ID, list[0].User.ID)
+
+	assert.ErrorIs(t, ensureNotBlocked(ctx, blocks, blocker.ID, blocked.ID), errors.ErrNotFound)
+	assert.NoError(t, ensureNotBlocked(ctx, blocks, blocked.ID, blocker.ID))
+
+	require.NoError(t, uc.UnblockUser(ctx, blocker.ID, blocked.ID))
+	list, err = uc.ListBlockedUsers(ctx, blocker.ID)
+	require.NoError(t, err)
+	assert.Empty(t, list)
+	assert.NoError(t, ensureNotBlocked(ctx, blocks, blocker.ID, blocked.ID))
+}
@@ -0,0 +1,296 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/jefersonprimer/chatear/backend/domain/entities"
+	"github.com/jefersonprimer/chatear/backend/domain/repositories"
+	"github.com/jefersonprimer/chatear/backend/shared/constants"
+	"github.com/jefersonprimer/chatear/backend/shared/errors"
+	"github.com/jefersonprimer/chatear/backend/shared/events"
+)
+
+// ContactEntry is a contact of a user along with when they became contacts.
+type ContactEntry struct {
+	User  *entities.User
+	Since time.Time
+}
+
+// ContactRequestEntry is a contact request along with both users involved.
+type ContactRequestEntry struct {
+	Request   *entities.ContactRequest
+	Sender    *entities.User
+	Recipient *entities.User
+}
+
+// Contacts is the use case for contacts, contact requests and who may message whom.
+type Contacts struct {
+	ContactRepository   repositories.ContactRepository
+	UserBlockRepository repositories.UserBlockRepository
+	UserRepository      repositories.UserRepository
+	EventBus            repositories.EventBus
+}
+
+// NewContacts creates a new Contacts use case.
+func NewContacts(
+	contactRepo repositories.ContactRepository,
+	userBlockRepo repositories.UserBlockRepository,
+	userRepo repositories.UserRepository,
+	eventBus repositories.EventBus,
+) *Contacts {
+	return &Contacts{
+		ContactRepository:   contactRepo,
+		UserBlockRepository: userBlockRepo,
+		UserRepository:      userRepo,
+		EventBus:            eventBus,
+	}
+}
+
+// SendContactRequest asks another user to become a contact. If they already asked the sender,
+// their request is accepted instead of opening a second one.
+func (uc *Contacts) SendContactRequest(ctx context.Context, senderID, recipientID uuid.UUID) (*ContactRequestEntry, error) {
+	if senderID == recipientID {
+		return nil, errors.ErrCannotContactSelf
+	}
+
+	recipient, err := uc.findActiveUser(ctx, recipientID)
+	if err != nil {
+		return nil, err
+	}
+	err = ensureNotBlocked(ctx, uc.UserBlockRepository, recipient.ID, senderID)
+	if err == errors.ErrNotFound {
+		return nil, errors.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	blockedByYou, err := uc.UserBlockRepository.IsBlocked(ctx, senderID, recipient.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check block list: %w", err)
+	}
+	if blockedByYou {
+		return nil, errors.ErrUserBlockedByYou
+	}
+
+	contacts, err := uc.ContactRepository.AreContacts(ctx, senderID, recipient.ID)
+	if err != nil {
+		return nil, err
+	}
+	if contacts {
+		return nil, errors.ErrAlreadyContacts
+	}
+
+	pending, err := uc.ContactRepository.FindPendingRequestBetween(ctx, senderID, recipient.ID)
+	if err != nil {
+		return nil, err
+	}
+	if pending != nil {
+		if pending.SenderID == senderID {
+			return nil, errors.ErrContactRequestExists
+		}
+		return uc.AcceptContactRequest(ctx, senderID, pending.ID)
+	}
+
+	// Re-sending right after a cancel or decline would notify the recipient again each time
+	closedRecently, err := uc.ContactRepository.HasRequestClosedSince(ctx, senderID, recipient.ID, time.Now().Add(-constants.ContactRequestCooldown))
+	if err != nil {
+		return nil, fmt.Errorf("failed to check recent contact requests: %w", err)
+	}
+	if closedRecently {
+		return nil, errors.ErrContactResendTooSoon
+	}
+
+	sender, err := uc.UserRepository.FindByID(ctx, senderID)
+	if err != nil {
+		return nil, err
+	}
+
+	request := entities.NewContactRequest(sender.ID, recipient.ID)
+	err = uc.ContactRepository.CreateRequest(ctx, request)
+	if err == errors.ErrContactRequestExists {
+		// The recipient may have sent a request the other way at the same moment; accept it as above
+		pending, findErr := uc.ContactRepository.FindPendingRequestBetween(ctx, senderID, recipient.ID)
+		if findErr == nil && pending != nil && pending.SenderID != senderID {
+			return uc.AcceptContactRequest(ctx, senderID, pending.ID)
+		}
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create contact request: %w", err)
+	}
+
+	receivedEvent := events.ContactRequestReceivedEvent{
+		RequestID:  request.ID.String(),
+		UserID:     recipient.ID.String(),
+		Email:      recipient.Email,
+		Name:       recipient.Name,
+		SenderName: sender.Name,
+		Timestamp:  time.Now(),
+	}
+	if err := uc.EventBus.Publish(ctx, events.ContactRequestReceivedSubject, receivedEvent); err != nil {
+		// Log the error but don't return it, as the request was created
+		fmt.Printf("failed to publish ContactRequestReceivedEvent for request %s: %v\n", request.ID.String(), err)
+	}
+
+	return &ContactRequestEntry{Request: request, Sender: sender, Recipient: recipient}, nil
+}
+
+// AcceptContactRequest accepts a request addressed to userID, making both users contacts.
+func (uc *Contacts) AcceptContactRequest(ctx context.Context, userID, requestID uuid.UUID) (*ContactRequestEntry, error) {
+	request, err := uc.findPendingRequest(ctx, requestID, func(r *entities.ContactRequest) bool { return r.RecipientID == userID })
+	if err != nil {
+		return nil, err
+	}
+
+	request.Close(entities.ContactRequestStatusAccepted)
+	err = uc.ContactRepository.AcceptRequest(ctx, request)
+	if err == errors.ErrContactRequestClosed {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to accept contact request: %w", err)
+	}
+	return uc.toEntry(ctx, request)
+}
+
+// DeclineContactRequest declines a request addressed to userID. The sender is not told.
+func (uc *Contacts) DeclineContactRequest(ctx context.Context, userID, requestID uuid.UUID) (*ContactRequestEntry, error) {
+	return uc.closeRequest(ctx, requestID, entities.ContactRequestStatusDeclined, func(r *entities.ContactRequest) bool { return r.RecipientID == userID })
+}
+
+// CancelContactRequest withdraws a request sent by userID.
+func (uc *Contacts) CancelContactRequest(ctx context.Context, userID, requestID uuid.UUID) (*ContactRequestEntry, error) {
+	return uc.closeRequest(ctx, requestID, entities.ContactRequestStatusCancelled, func(r *entities.ContactRequest) bool { return r.SenderID == userID })
+}
+
+// RemoveContact ends a contact relationship for both users.
+func (uc *Contacts) RemoveContact(ctx context.Context, userID, contactID uuid.UUID) error {
+	if err := uc.ContactRepository.RemoveContact(ctx, userID, contactID); err != nil {
+		return fmt.Errorf("failed to remove contact: %w", err)
+	}
+	return nil
+}
+
+// ListContacts returns the contacts of a user, most recent first. Accounts that were deleted since are left out.
+func (uc *Contacts) ListContacts(ctx context.Context, userID uuid.UUID) ([]*ContactEntry, error) {
+	contacts, err := uc.ContactRepository.FindContactsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*ContactEntry, 0, len(contacts))
+	for _, contact := range contacts {
+		entries = append(entries, &ContactEntry{User: contact.Contact, Since: contact.CreatedAt})
+	}
+	return entries, nil
+}
+
+// ListContactRequests returns the pending requests a user received, or the ones they sent when
+// outgoing is true, most recent first.
+func (uc *Contacts) ListContactRequests(ctx context.Context, userID uuid.UUID, outgoing bool) ([]*ContactRequestEntry, error) {
+	var requests []*entities.ContactRequest
+	var err error
+	if outgoing {
+		requests, err = uc.ContactRepository.FindPendingRequestsBySenderID(ctx, userID)
+	} else {
+		requests, err = uc.ContactRepository.FindPendingRequestsByRecipientID(ctx, userID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*ContactRequestEntry, 0, len(requests))
+	for _, request := range requests {
+		entries = append(entries, &ContactRequestEntry{Request: request, Sender: request.Sender, Recipient: request.Recipient})
+	}
+	return entries, nil
+}
+
+// CanDirectMessage checks whether senderID may start a direct conversation with recipientID,
+// honouring blocks and the recipient's privacy setting.
+func (uc *Contacts) CanDirectMessage(ctx context.Context, senderID, recipientID uuid.UUID) error {
+	recipient, err := uc.findActiveUser(ctx, recipientID)
+	if err != nil {
+		return err
+	}
+	err = ensureNotBlocked(ctx, uc.UserBlockRepository, recipient.ID, senderID)
+	if err == errors.ErrNotFound {
+		return errors.ErrUserNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if recipient.DMPrivacy != entities.DMPrivacyContacts || senderID == recipient.ID {
+		return nil
+	}
+
+	contacts, err := uc.ContactRepository.AreContacts(ctx, recipient.ID, senderID)
+	if err != nil {
+		return err
+	}
+	if !contacts {
+		return errors.ErrDirectMessagesClosed
+	}
+	return nil
+}
+
+func (uc *Contacts) closeRequest(ctx context.Context, requestID uuid.UUID, status string, allowed func(*entities.ContactRequest) bool) (*ContactRequestEntry, error) {
+	request, err := uc.findPendingRequest(ctx, requestID, allowed)
+	if err != nil {
+		return nil, err
+	}
+
+	request.Close(status)
+	err = uc.ContactRepository.UpdateRequest(ctx, request)
+	if err == errors.ErrContactRequestClosed {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update contact request: %w", err)
+	}
+	return uc.toEntry(ctx, request)
+}
+
+// findPendingRequest loads a request the caller is allowed to act on. Requests of other users
+// are reported as not found.
+func (uc *Contacts) findPendingRequest(ctx context.Context, requestID uuid.UUID, allowed func(*entities.ContactRequest) bool) (*entities.ContactRequest, error) {
+	request, err := uc.ContactRepository.FindRequestByID(ctx, requestID)
+	if err == pgx.ErrNoRows || (err == nil && !allowed(request)) {
+		return nil, errors.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !request.IsPending() {
+		return nil, errors.ErrContactRequestClosed
+	}
+	return request, nil
+}
+
+func (uc *Contacts) toEntry(ctx context.Context, request *entities.ContactRequest) (*ContactRequestEntry, error) {
+	sender, err := uc.findActiveUser(ctx, request.SenderID)
+	if err != nil {
+		return nil, err
+	}
+	recipient, err := uc.findActiveUser(ctx, request.RecipientID)
+	if err != nil {
+		return nil, err
+	}
+	return &ContactRequestEntry{Request: request, Sender: sender, Recipient: recipient}, nil
+}
+
+func (uc *Contacts) findActiveUser(ctx context.Context, userID uuid.UUID) (*entities.User, error) {
+	user, err := uc.UserRepository.FindByID(ctx, userID)
+	if err == pgx.ErrNoRows || (err == nil && user.IsDeleted) {
+		return nil, errors.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
@@ -0,0 +1,328 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jefersonprimer/chatear/backend/domain/entities"
+	"github.com/jefersonprimer/chatear/backend/shared/constants"
+	"github.com/jefersonprimer/chatear/backend/shared/errors"
+	"github.com/jefersonprimer/chatear/backend/shared/events"
+)
+
+// MockContactRepository is an in-memory implementation of repositories.ContactRepository
+type MockContactRepository struct {
+	requests map[uuid.UUID]*entities.ContactRequest
+	closed   map[uuid.UUID]bool
+	contacts map[uuid.UUID]map[uuid.UUID]time.Time
+	// users, if set, is where the list methods load accounts from
+	users *MockUserRepository
+}
+
+func NewMockContactRepository() *MockContactRepository {
+	return &MockContactRepository{
+		requests: map[uuid.UUID]*entities.ContactRequest{},
+		closed:   map[uuid.UUID]bool{},
+		contacts: map[uuid.UUID]map[uuid.UUID]time.Time{},
+	}
+}
+
+func (m *MockContactRepository) CreateRequest(ctx context.Context, request *entities.ContactRequest) error {
+	if pending, _ := m.FindPendingRequestBetween(ctx, request.SenderID, request.RecipientID); pending != nil {
+		return errors.ErrContactRequestExists
+	}
+	m.requests[request.ID] = request
+	return nil
+}
+
+func (m *MockContactRepository) FindRequestByID(ctx context.Context, id uuid.UUID) (*entities.ContactRequest, error) {
+	request, ok := m.requests[id]
+	if !ok {
+		return nil, pgx.ErrNoRows
+	}
+	return request, nil
+}
+
+func (m *MockContactRepository) FindPendingRequestBetween(ctx context.Context, userID, otherUserID uuid.UUID) (*entities.ContactRequest, error) {
+	for _, request := range m.requests {
+		between := (request.SenderID == userID && request.RecipientID == otherUserID) || (request.SenderID == otherUserID && request.RecipientID == userID)
+		if between && request.IsPending() {
+			return request, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockContactRepository) HasRequestClosedSince(ctx context.Context, senderID, recipientID uuid.UUID, since time.Time) (bool, error) {
+	for _, request := range m.requests {
+		closed := request.Status == entities.ContactRequestStatusCancelled || request.Status == entities.ContactRequestStatusDeclined
+		if request.SenderID == senderID && request.RecipientID == recipientID && closed && request.RespondedAt.After(since) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *MockContactRepository) FindPendingRequestsByRecipientID(ctx context.Context, recipientID uuid.UUID) ([]*entities.ContactRequest, error) {
+	return m.findPendingRequests(func(r *entities.ContactRequest) bool { return r.RecipientID == recipientID }), nil
+}
+
+func (m *MockContactRepository) FindPendingRequestsBySenderID(ctx context.Context, senderID uuid.UUID) ([]*entities.ContactRequest, error) {
+	return m.findPendingRequests(func(r *entities.ContactRequest) bool { return r.SenderID == senderID }), nil
+}
+
+func (m *MockContactRepository) findPendingRequests(match func(*entities.ContactRequest) bool) []*entities.ContactRequest {
+	var requests []*entities.ContactRequest
+	for _, request := range m.requests {
+		if !match(request) || !request.IsPending() {
+			continue
+		}
+		sender, recipient := m.activeUser(request.SenderID), m.activeUser(request.RecipientID)
+		if sender != nil && recipient != nil {
+			request.Sender, request.Recipient = sender, recipient
+			requests = append(requests, request)
+		}
+	}
+	return requests
+}
+
+func (m *MockContactRepository) activeUser(id uuid.UUID) *entities.User {
+	if m.users == nil {
+		return nil
+	}
+	if user, ok := m.users.users[id]; ok && !user.IsDeleted {
+		return user
+	}
+	return nil
+}
+
+func (m *MockContactRepository) UpdateRequest(ctx context.Context, request *entities.ContactRequest) error {
+	if m.closed[request.ID] {
+		return errors.ErrContactRequestClosed
+	}
+	m.closed[request.ID] = true
+	m.requests[request.ID] = request
+	return nil
+}
+
+func (m *MockContactRepository) AcceptRequest(ctx context.Context, request *entities.ContactRequest) error {
+	if err := m.UpdateRequest(ctx, request); err != nil {
+		return err
+	}
+	m.addContact(request.SenderID, request.RecipientID)
+	m.addContact(request.RecipientID, request.SenderID)
+	return nil
+}
+
+func (m *MockContactRepository) addContact(userID, contactID uuid.UUID) {
+	if m.contacts[userID] == nil {
+		m.contacts[userID] = map[uuid.UUID]time.Time{}
+	}
+	m.contacts[userID][contactID] = time.Now()
+}
+
+func (m *MockContactRepository) CancelPendingRequestsBetween(ctx context.Context, userID, otherUserID uuid.UUID) error {
+	for {
+		request, _ := m.FindPendingRequestBetween(ctx, userID, otherUserID)
+		if request == nil {
+			return nil
+		}
+		request.Close(entities.ContactRequestStatusCancelled)
+	}
+}
+
+func (m *MockContactRepository) AreContacts(ctx context.Context, userID, otherUserID uuid.UUID) (bool, error) {
+	_, ok := m.contacts[userID][otherUserID]
+	return ok, nil
+}
+
+func (m *MockContactRepository) FindContactsByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.Contact, error) {
+	var contacts []*entities.Contact
+	for contactID, since := range m.contacts[userID] {
+		if user := m.activeUser(contactID); user != nil {
+			contacts = append(contacts, &entities.Contact{UserID: userID, ContactID: contactID, CreatedAt: since, Contact: user})
+		}
+	}
+	return contacts, nil
+}
+
+func (m *MockContactRepository) RemoveContact(ctx context.Context, userID, otherUserID uuid.UUID) error {
+	delete(m.contacts[userID], otherUserID)
+	delete(m.contacts[otherUserID], userID)
+	return nil
+}
+
+func newTestContacts(users ...*entities.User) (*Contacts, *MockUserBlockRepository, *MockEventBus) {
+	userRepo := NewMockUserRepository(users...)
+	contacts := NewMockContactRepository()
+	contacts.users = userRepo
+	blocks := NewMockUserBlockRepository()
+	blocks.users = userRepo
+	bus := &MockEventBus{}
+	return NewContacts(contacts, blocks, userRepo, bus), blocks, bus
+}
+
+func TestContacts_RequestLifecycle(t *testing.T) {
+	ctx := context.Background()
+	alice := entities.NewUser("Alice", "alice@example.com", "hash", "FEMALE")
+	bob := entities.NewUser("Bob", "bob@example.com", "hash", "MALE")
+	uc, _, bus := newTestContacts(alice, bob)
+
+	_, err := uc.SendContactRequest(ctx, alice.ID, alice.ID)
+	assert.ErrorIs(t, err, errors.ErrCannotContactSelf)
+
+	request, err := uc.SendContactRequest(ctx, alice.ID, bob.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{events.ContactRequestReceivedSubject}, bus.Published)
+
+	_, err = uc.SendContactRequest(ctx, alice.ID, bob.ID)
+	assert.ErrorIs(t, err, errors.ErrContactRequestExists)
+
+	_, err = uc.AcceptContactRequest(ctx, alice.ID, request.Request.ID)
+	assert.ErrorIs(t, err, errors.ErrNotFound)
+
+	incoming, err := uc.ListContactRequests(ctx, bob.ID, false)
+	require.NoError(t, err)
+	require.Len(t, incoming, 1)
+	assert.Equal(t, "Alice", incoming[0].Sender.Name)
+
+	accepted, err := uc.AcceptContactRequest(ctx, bob.ID, request.Request.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.ContactRequestStatusAccepted, accepted.Request.Status)
+
+	_, err = uc.DeclineContactRequest(ctx, bob.ID, request.Request.ID)
+	assert.ErrorIs(t, err, errors.ErrContactRequestClosed)
+
+	_, err = uc.SendContactRequest(ctx, bob.ID, alice.ID)
+	assert.ErrorIs(t, err, errors.ErrAlreadyContacts)
+
+	contacts, err := uc.ListContacts(ctx, alice.ID)
+	require.NoError(t, err)
+	require.Len(t, contacts, 1)
+	assert.Equal(t, bob.ID, contacts[0].User.ID)
+
+	require.NoError(t, uc.RemoveContact(ctx, bob.ID, alice.ID))
+	contacts, err = uc.ListContacts(ctx, alice.ID)
+	require.NoError(t, err)
+	assert.Empty(t, contacts)
+}
+
+func TestContacts_CrossedRequestsAreAccepted(t *testing.T) {
+	ctx := context.Background()
+	alice := entities.NewUser("Alice", "alice@example.com", "hash", "FEMALE")
+	bob := entities.NewUser("Bob", "bob@example.com", "hash", "MALE")
+	uc, _, _ := newTestContacts(alice, bob)
+
+	_, err := uc.SendContactRequest(ctx, alice.ID, bob.ID)
+	require.NoError(t, err)
+
+	request, err := uc.SendContactRequest(ctx, bob.ID, alice.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.ContactRequestStatusAccepted, request.Request.Status)
+	assert.Equal(t, alice.ID, request.Sender.ID)
+}
+
+func TestContacts_ResendCooldown(t *testing.T) {
+	ctx := context.Background()
+	alice := entities.NewUser("Alice", "alice@example.com", "hash", "FEMALE")
+	bob := entities.NewUser("Bob", "bob@example.com", "hash", "MALE")
+	uc, _, bus := newTestContacts(alice, bob)
+
+	request, err := uc.SendContactRequest(ctx, alice.ID, bob.ID)
+	require.NoError(t, err)
+	_, err = uc.CancelContactRequest(ctx, alice.ID, request.Request.ID)
+	require.NoError(t, err)
+
+	_, err = uc.SendContactRequest(ctx, alice.ID, bob.ID)
+	assert.ErrorIs(t, err, errors.ErrContactResendTooSoon)
+	assert.Len(t, bus.Published, 1)
+
+	respondedAt := time.Now().Add(-constants.ContactRequestCooldown - time.Minute)
+	request.Request.RespondedAt = &respondedAt
+	_, err = uc.SendContactRequest(ctx, alice.ID, bob.ID)
+	require.NoError(t, err)
+}
+
+func TestContacts_ConcurrentlyClosedRequest(t *testing.T) {
+	ctx := context.Background()
+	alice := entities.NewUser("Alice", "alice@example.com", "hash", "FEMALE")
+	bob := entities.NewUser("Bob", "bob@example.com", "hash", "MALE")
+	uc, _, _ := newTestContacts(alice, bob)
+
+	request, err := uc.SendContactRequest(ctx, alice.ID, bob.ID)
+	require.NoError(t, err)
+
+	// Alice cancels in the window between Bob loading the request and accepting it.
+	uc.ContactRepository.(*MockContactRepository).closed[request.Request.ID] = true
+
+	_, err = uc.AcceptContactRequest(ctx, bob.ID, request.Request.ID)
+	assert.ErrorIs(t, err, errors.ErrContactRequestClosed)
+
+	contacts, err := uc.ListContacts(ctx, bob.ID)
+	require.NoError(t, err)
+	assert.Empty(t, contacts)
+}
+
+func TestContacts_SimultaneousCrossedRequests(t *testing.T) {
+	ctx := context.Background()
+	alice := entities.NewUser("Alice", "alice@example.com", "hash", "FEMALE")
+	bob := entities.NewUser("Bob", "bob@example.com", "hash", "MALE")
+	uc, _, _ := newTestContacts(alice, bob)
+	repo := uc.ContactRepository.(*MockContactRepository)
+
+	// Bob's request lands after Alice's pending check but before her insert
+	uc.ContactRepository = &racingContactRepository{MockContactRepository: repo, before: func() {
+		require.NoError(t, repo.CreateRequest(ctx, entities.NewContactRequest(bob.ID, alice.ID)))
+	}}
+
+	request, err := uc.SendContactRequest(ctx, alice.ID, bob.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.ContactRequestStatusAccepted, request.Request.Status)
+	assert.Equal(t, bob.ID, request.Sender.ID)
+}
+
+// racingContactRepository runs before just ahead of the first CreateRequest
+type racingContactRepository struct {
+	*MockContactRepository
+	before func()
+}
+
+func (r *racingContactRepository) CreateRequest(ctx context.Context, request *entities.ContactRequest) error {
+	if r.before != nil {
+		r.before()
+		r.before = nil
+	}
+	return r.MockContactRepository.CreateRequest(ctx, request)
+}
+
+func TestContacts_BlocksAndPrivacy(t *testing.T) {
+	ctx := context.Background()
+	alice := entities.NewUser("Alice", "alice@example.com", "hash", "FEMALE")
+	bob := entities.NewUser("Bob", "bob@example.com", "hash", "MALE")
+	uc, blocks, _ := newTestContacts(alice, bob)
+
+	require.NoError(t, blocks.Create(ctx, entities.NewUserBlock(bob.ID, alice.ID)))
+	_, err := uc.SendContactRequest(ctx, alice.ID, bob.ID)
+	assert.ErrorIs(t, err, errors.ErrUserNotFound)
+	_, err = uc.SendContactRequest(ctx, bob.ID, alice.ID)
+	assert.ErrorIs(t, err, errors.ErrUserBlockedByYou)
+	assert.ErrorIs(t, uc.CanDirectMessage(ctx, alice.ID, bob.ID), errors.ErrUserNotFound)
+	require.NoError(t, blocks.Delete(ctx, bob.ID, alice.ID))
+
+	assert.NoError(t, uc.CanDirectMessage(ctx, alice.ID, bob.ID))
+
+	bob.DMPrivacy = entities.DMPrivacyContacts
+	assert.ErrorIs(t, uc.CanDirectMessage(ctx, alice.ID, bob.ID), errors.ErrDirectMessagesClosed)
+
+	request, err := uc.SendContactRequest(ctx, alice.ID, bob.ID)
+	require.NoError(t, err)
+	_, err = uc.AcceptContactRequest(ctx, bob.ID, request.Request.ID)
+	require.NoError(t, err)
+	assert.NoError(t, uc.CanDirectMessage(ctx, alice.ID, bob.ID))
+}
@@ -0,0 +1,230 @@
+package application
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/jefersonprimer/chatear/backend/domain/entities"
+	"github.com/jefersonprimer/chatear/backend/domain/repositories"
+	notificationApp "github.com/jefersonprimer/chatear/backend/internal/notification/application"
+	"github.com/jefersonprimer/chatear/backend/shared/constants"
+	"github.com/jefersonprimer/chatear/backend/shared/errors"
+	"github.com/jefersonprimer/chatear/backend/shared/events"
+)
+
+// OneTimePrekeyInput is a public one-time prekey uploaded by a device.
+type OneTimePrekeyInput struct {
+	KeyID     int
+	PublicKey string
+}
+
+// PublishDeviceKeysRequest represents the key material a device publishes to the directory.
+type PublishDeviceKeysRequest struct {
+	DeviceID              int
+	IdentityKey           string
+	SignedPrekeyID        int
+	SignedPrekey          string
+	SignedPrekeySignature string
+	OneTimePrekeys        []OneTimePrekeyInput
+}
+
+// KeyDirectory is the use case for distributing end-to-end encryption public keys.
+// The server only ever stores and hands out public key material.
+type KeyDirectory struct {
+	DeviceKeysRepository repositories.DeviceKeysRepository
+	UserBlockRepository  repositories.UserBlockRepository
+	EventBus             repositories.EventBus
+	ClaimLimiter         notificationApp.RateLimiter
+}
+
+// NewKeyDirectory creates a new KeyDirectory use case.
+func NewKeyDirectory(deviceKeysRepo repositories.DeviceKeysRepository, userBlockRepo repositories.UserBlockRepository, eventBus repositories.EventBus, claimLimiter notificationApp.RateLimiter) *KeyDirectory {
+	return &KeyDirectory{
+		DeviceKeysRepository: deviceKeysRepo,
+		UserBlockRepository:  userBlockRepo,
+		EventBus:             eventBus,
+		ClaimLimiter:         claimLimiter,
+	}
+}
+
+// PublishDeviceKeys registers a device or rotates its keys. Replacing the identity key drops the
+// prekeys generated for the old identity and announces a safety number change.
+func (uc *KeyDirectory) PublishDeviceKeys(ctx context.Context, userID uuid.UUID, req PublishDeviceKeysRequest) (*entities.DeviceKeys, error) {
+	if req.DeviceID <= 0 {
+		return nil, fmt.Errorf("%w: device ID must be positive", errors.ErrInvalidKeyMaterial)
+	}
+	if err := validatePublicKey(req.IdentityKey); err != nil {
+		return nil, err
+	}
+	if err := validatePublicKey(req.SignedPrekey); err != nil {
+		return nil, err
+	}
+	if err := validateSignatureLength(req.SignedPrekeySignature); err != nil {
+		return nil, err
+	}
+	prekeys, err := toOneTimePrekeys(userID, req.DeviceID, req.OneTimePrekeys)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.DeviceKeysRepository.FindDevice(ctx, userID, req.DeviceID)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, fmt.Errorf("failed to look up device keys: %w", err)
+	}
+
+	device := entities.NewDeviceKeys(userID, req.DeviceID, req.IdentityKey, req.SignedPrekeyID, req.SignedPrekey, req.SignedPrekeySignature)
+	identityChanged := existing != nil && existing.IdentityKey != req.IdentityKey
+	if existing != nil {
+		device.CreatedAt = existing.CreatedAt
+	}
+
+	if err := uc.DeviceKeysRepository.PublishDevice(ctx, device, prekeys, identityChanged); err != nil {
+		return nil, fmt.Errorf("failed to store device keys: %w", err)
+	}
+
+	if identityChanged {
+		identityChangedEvent := events.IdentityKeyChangedEvent{
+			UserID:    userID.String(),
+			DeviceID:  req.DeviceID,
+			Timestamp: time.Now(),
+		}
+		if err := uc.EventBus.Publish(ctx, events.IdentityKeyChangedSubject, identityChangedEvent); err != nil {
+			// Log the error but don't return it, as the keys were stored
+			fmt.Printf("failed to publish IdentityKeyChangedEvent for user %s: %v\n", userID.String(), err)
+		}
+	}
+
+	return device, nil
+}
+
+// UploadOneTimePrekeys adds a batch of one-time prekeys to a registered device and returns how many are available.
+func (uc *KeyDirectory) UploadOneTimePrekeys(ctx context.Context, userID uuid.UUID, deviceID int, inputs []OneTimePrekeyInput) (int, error) {
+	if _, err := uc.findDevice(ctx, userID, deviceID); err != nil {
+		return 0, err
+	}
+
+	prekeys, err := toOneTimePrekeys(userID, deviceID, inputs)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(prekeys) > 0 {
+		if err := uc.DeviceKeysRepository.AddOneTimePrekeys(ctx, prekeys); err != nil {
+			return 0, fmt.Errorf("failed to store one-time prekeys: %w", err)
+		}
+	}
+
+	return uc.DeviceKeysRepository.CountOneTimePrekeys(ctx, userID, deviceID)
+}
+
+// ClaimPrekeyBundle returns the keys needed to start a session with a device, consuming one of its
+// one-time prekeys. The bundle has no one-time prekey once the device has run out. Users blocked
+// by the device owner cannot claim bundles, so they cannot start a session with them. Claims are
+// limited per requester and device, so nobody can drain another user's prekeys.
+func (uc *KeyDirectory) ClaimPrekeyBundle(ctx context.Context, requesterID, userID uuid.UUID, deviceID int) (*entities.PrekeyBundle, error) {
+	if err := ensureNotBlocked(ctx, uc.UserBlockRepository, userID, requesterID); err != nil {
+		return nil, err
+	}
+
+	device, err := uc.findDevice(ctx, userID, deviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	limitKey := fmt.Sprintf("prekey_claim:%s:%s:%d", requesterID, userID, deviceID)
+	isAllowed, err := uc.ClaimLimiter.IsAllowed(ctx, limitKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check prekey claim rate limit: %w", err)
+	}
+	if !isAllowed {
+		return nil, errors.ErrRateLimitExceeded
+	}
+	if err := uc.ClaimLimiter.Increment(ctx, limitKey); err != nil {
+		return nil, fmt.Errorf("failed to count prekey claim: %w", err)
+	}
+
+	prekey, remaining, err := uc.DeviceKeysRepository.ClaimOneTimePrekey(ctx, userID, deviceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim one-time prekey: %w", err)
+	}
+
+	if prekey != nil && (remaining == constants.PrekeyLowThreshold-1 || remaining == 0) {
+		// Only announce when this claim took the pool below the threshold or emptied it, so a device
+		// that is slow to refill is not notified once per incoming session
+		prekeysLowEvent := events.PrekeysLowEvent{
+			UserID:    userID.String(),
+			DeviceID:  deviceID,
+			Remaining: remaining,
+			Timestamp: time.Now(),
+		}
+		if err := uc.EventBus.Publish(ctx, events.PrekeysLowSubject, prekeysLowEvent); err != nil {
+			fmt.Printf("failed to publish PrekeysLowEvent for user %s: %v\n", userID.String(), err)
+		}
+	}
+
+	return &entities.PrekeyBundle{Device: device, OneTimePrekey: prekey}, nil
+}
+
+// ListDevices returns the published keys of every device of a user. Users blocked by the
+// owner get an error instead.
+func (uc *KeyDirectory) ListDevices(ctx context.Context, requesterID, userID uuid.UUID) ([]*entities.DeviceKeys, error) {
+	if err := ensureNotBlocked(ctx, uc.UserBlockRepository, userID, requesterID); err != nil {
+		return nil, err
+	}
+	return uc.DeviceKeysRepository.FindDevicesByUserID(ctx, userID)
+}
+
+// CountOneTimePrekeys returns how many unclaimed prekeys a device has left.
+func (uc *KeyDirectory) CountOneTimePrekeys(ctx context.Context, userID uuid.UUID, deviceID int) (int, error) {
+	return uc.DeviceKeysRepository.CountOneTimePrekeys(ctx, userID, deviceID)
+}
+
+func (uc *KeyDirectory) findDevice(ctx context.Context, userID uuid.UUID, deviceID int) (*entities.DeviceKeys, error) {
+	device, err := uc.DeviceKeysRepository.FindDevice(ctx, userID, deviceID)
+	if err == pgx.ErrNoRows {
+		return nil, errors.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return device, nil
+}
+
+func toOneTimePrekeys(userID uuid.UUID, deviceID int, inputs []OneTimePrekeyInput) ([]*entities.OneTimePrekey, error) {
+	if len(inputs) > constants.MaxOneTimePrekeysPerUpload {
+		return nil, errors.ErrTooManyPrekeys
+	}
+
+	prekeys := make([]*entities.OneTimePrekey, 0, len(inputs))
+	for _, input := range inputs {
+		if err := validatePublicKey(input.PublicKey); err != nil {
+			return nil, err
+		}
+		prekeys = append(prekeys, entities.NewOneTimePrekey(userID, deviceID, input.KeyID, input.PublicKey))
+	}
+	return prekeys, nil
+}
+
+// validatePublicKey accepts base64 Curve25519 public keys, with or without the one-byte type prefix.
+func validatePublicKey(key string) error {
+	raw, err := base64.StdEncoding.DecodeString(key)
+	if err != nil || (len(raw) != 32 && len(raw) != 33) {
+		return fmt.Errorf("%w: public keys must be 32 or 33 base64-encoded bytes", errors.ErrInvalidKeyMaterial)
+	}
+	return nil
+}
+
+// validateSignatureLength accepts a base64 64-byte signature. The server does not verify signatures;
+// clients check the signed prekey against the identity key before trusting a bundle.
+func validateSignatureLength(signature string) error {
+	raw, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil || len(raw) != 64 {
+		return fmt.Errorf("%w: signatures must be 64 base64-encoded bytes", errors.ErrInvalidKeyMaterial)
+	}
+	return nil
+}
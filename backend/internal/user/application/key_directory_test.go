@@ -0,0 +1,266 @@
+package application
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jefersonprimer/chatear/backend/domain/entities"
+	"github.com/jefersonprimer/chatear/backend/shared/constants"
+	"github.com/jefersonprimer/chatear/backend/shared/errors"
+	"github.com/jefersonprimer/chatear/backend/shared/events"
+)
+
+// MockDeviceKeysRepository is an in-memory implementation of repositories.DeviceKeysRepository
+type MockDeviceKeysRepository struct {
+	devices map[int]*entities.DeviceKeys
+	prekeys map[int][]*entities.OneTimePrekey
+}
+
+func NewMockDeviceKeysRepository() *MockDeviceKeysRepository {
+	return &MockDeviceKeysRepository{
+		devices: map[int]*entities.DeviceKeys{},
+		prekeys: map[int][]*entities.OneTimePrekey{},
+	}
+}
+
+func (m *MockDeviceKeysRepository) PublishDevice(ctx context.Context, device *entities.DeviceKeys, prekeys []*entities.OneTimePrekey, replacePrekeys bool) error {
+	m.devices[device.DeviceID] = device
+	if replacePrekeys {
+		delete(m.prekeys, device.DeviceID)
+	}
+	return m.AddOneTimePrekeys(ctx, prekeys)
+}
+
+func (m *MockDeviceKeysRepository) FindDevice(ctx context.Context, userID uuid.UUID, deviceID int) (*entities.DeviceKeys, error) {
+	device, ok := m.devices[deviceID]
+	if !ok {
+		return nil, pgx.ErrNoRows
+	}
+	return device, nil
+}
+
+func (m *MockDeviceKeysRepository) FindDevicesByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.DeviceKeys, error) {
+	var devices []*entities.DeviceKeys
+	for _, device := range m.devices {
+		devices = append(devices, device)
+	}
+	return devices, nil
+}
+
+func (m *MockDeviceKeysRepository) AddOneTimePrekeys(ctx context.Context, prekeys []*entities.OneTimePrekey) error {
+	for _, prekey := range prekeys {
+		m.prekeys[prekey.DeviceID] = append(m.prekeys[prekey.DeviceID], prekey)
+	}
+	return nil
+}
+
+func (m *MockDeviceKeysRepository) ClaimOneTimePrekey(ctx context.Context, userID uuid.UUID, deviceID int) (*entities.OneTimePrekey, int, error) {
+	if len(m.prekeys[deviceID]) == 0 {
+		return nil, 0, nil
+	}
+	prekey := m.prekeys[deviceID][0]
+	m.prekeys[deviceID] = m.prekeys[deviceID][1:]
+	return prekey, len(m.prekeys[deviceID]), nil
+}
+
+func (m *MockDeviceKeysRepository) CountOneTimePrekeys(ctx context.Context, userID uuid.UUID, deviceID int) (int, error) {
+	return len(m.prekeys[deviceID]), nil
+}
+
+// MockEventBus records published subjects
+type MockEventBus struct {
+	Published []string
+	// Err, if set, fails every publish
+	Err error
+}
+
+func (m *MockEventBus) Publish(ctx context.Context, subject string, data interface{}) error {
+	if m.Err != nil {
+		return m.Err
+	}
+	m.Published = append(m.Published, subject)
+	return nil
+}
+
+func (m *MockEventBus) Subscribe(ctx context.Context, subject string, handler nats.MsgHandler) error {
+	return nil
+}
+
+// MockRateLimiter allows limit actions per key
+type MockRateLimiter struct {
+	limit  int
+	counts map[string]int
+}
+
+func NewMockRateLimiter(limit int) *MockRateLimiter {
+	return &MockRateLimiter{limit: limit, counts: map[string]int{}}
+}
+
+func (m *MockRateLimiter) Get(ctx context.Context, key string) (int, error) {
+	return m.counts[key], nil
+}
+
+func (m *MockRateLimiter) Increment(ctx context.Context, key string) error {
+	m.counts[key]++
+	return nil
+}
+
+func (m *MockRateLimiter) IsAllowed(ctx context.Context, key string) (bool, error) {
+	return m.counts[key] < m.limit, nil
+}
+
+func testKey(fill string) string {
+	return base64.StdEncoding.EncodeToString([]byte(strings.Repeat(fill, 32)))
+}
+
+func testPublishRequest(identityKey string, prekeyCount int) PublishDeviceKeysRequest {
+	req := PublishDeviceKeysRequest{
+		DeviceID:              1,
+		IdentityKey:           identityKey,
+		SignedPrekeyID:        1,
+		SignedPrekey:          testKey("s"),
+		SignedPrekeySignature: base64.StdEncoding.EncodeToString([]byte(strings.Repeat("x", 64))),
+	}
+	for i := 0; i < prekeyCount; i++ {
+		req.OneTimePrekeys = append(req.OneTimePrekeys, OneTimePrekeyInput{KeyID: i + 1, PublicKey: testKey("p")})
+	}
+	return req
+}
+
+func TestKeyDirectory_PublishDeviceKeys(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	t.Run("rejects malformed keys", func(t *testing.T) {
+		uc := NewKeyDirectory(NewMockDeviceKeysRepository(), NewMockUserBlockRepository(), &MockEventBus{}, NewMockRateLimiter(100))
+		_, err := uc.PublishDeviceKeys(ctx, userID, testPublishRequest("not-a-key", 0))
+		assert.ErrorIs(t, err, errors.ErrInvalidKeyMaterial)
+	})
+
+	t.Run("rejects oversized prekey batches", func(t *testing.T) {
+		uc := NewKeyDirectory(NewMockDeviceKeysRepository(), NewMockUserBlockRepository(), &MockEventBus{}, NewMockRateLimiter(100))
+		_, err := uc.PublishDeviceKeys(ctx, userID, testPublishRequest(testKey("i"), constants.MaxOneTimePrekeysPerUpload+1))
+		assert.ErrorIs(t, err, errors.ErrTooManyPrekeys)
+	})
+
+	t.Run("identity change drops prekeys and emits event", func(t *testing.T) {
+		repo := NewMockDeviceKeysRepository()
+		bus := &MockEventBus{}
+		uc := NewKeyDirectory(repo, NewMockUserBlockRepository(), bus, NewMockRateLimiter(100))
+
+		_, err := uc.PublishDeviceKeys(ctx, userID, testPublishRequest(testKey("a"), 5))
+		require.NoError(t, err)
+		assert.Empty(t, bus.Published)
+
+		_, err = uc.PublishDeviceKeys(ctx, userID, testPublishRequest(testKey("b"), 0))
+		require.NoError(t, err)
+		assert.Equal(t, []string{events.IdentityKeyChangedSubject}, bus.Published)
+
+		count, err := uc.CountOneTimePrekeys(ctx, userID, 1)
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+	})
+}
+
+func TestKeyDirectory_ClaimPrekeyBundle(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	requesterID := uuid.New()
+
+	t.Run("unknown device", func(t *testing.T) {
+		uc := NewKeyDirectory(NewMockDeviceKeysRepository(), NewMockUserBlockRepository(), &MockEventBus{}, NewMockRateLimiter(100))
+		_, err := uc.ClaimPrekeyBundle(ctx, requesterID, userID, 7)
+		assert.ErrorIs(t, err, errors.ErrNotFound)
+	})
+
+	t.Run("consumes one prekey per claim", func(t *testing.T) {
+		repo := NewMockDeviceKeysRepository()
+		bus := &MockEventBus{}
+		uc := NewKeyDirectory(repo, NewMockUserBlockRepository(), bus, NewMockRateLimiter(100))
+		_, err := uc.PublishDeviceKeys(ctx, userID, testPublishRequest(testKey("a"), constants.PrekeyLowThreshold+1))
+		require.NoError(t, err)
+
+		first, err := uc.ClaimPrekeyBundle(ctx, requesterID, userID, 1)
+		require.NoError(t, err)
+		require.NotNil(t, first.OneTimePrekey)
+		assert.Empty(t, bus.Published)
+
+		second, err := uc.ClaimPrekeyBundle(ctx, requesterID, userID, 1)
+		require.NoError(t, err)
+		require.NotNil(t, second.OneTimePrekey)
+		assert.NotEqual(t, first.OneTimePrekey.KeyID, second.OneTimePrekey.KeyID)
+		assert.Equal(t, []string{events.PrekeysLowSubject}, bus.Published)
+
+		// Further claims below the threshold stay quiet until the pool runs out
+		for i := 0; i < constants.PrekeyLowThreshold-2; i++ {
+			_, err = uc.ClaimPrekeyBundle(ctx, requesterID, userID, 1)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, []string{events.PrekeysLowSubject}, bus.Published)
+
+		_, err = uc.ClaimPrekeyBundle(ctx, requesterID, userID, 1)
+		require.NoError(t, err)
+		assert.Equal(t, []string{events.PrekeysLowSubject, events.PrekeysLowSubject}, bus.Published)
+
+		_, err = uc.ClaimPrekeyBundle(ctx, requesterID, userID, 1)
+		require.NoError(t, err)
+		assert.Len(t, bus.Published, 2)
+	})
+
+	t.Run("exhausted device still returns signed prekey", func(t *testing.T) {
+		uc := NewKeyDirectory(NewMockDeviceKeysRepository(), NewMockUserBlockRepository(), &MockEventBus{}, NewMockRateLimiter(100))
+		_, err := uc.PublishDeviceKeys(ctx, userID, testPublishRequest(testKey("a"), 0))
+		require.NoError(t, err)
+
+		bundle, err := uc.ClaimPrekeyBundle(ctx, requesterID, userID, 1)
+		require.NoError(t, err)
+		assert.Nil(t, bundle.OneTimePrekey)
+		assert.Equal(t, testKey("s"), bundle.Device.SignedPrekey)
+	})
+
+	t.Run("claims are limited per requester", func(t *testing.T) {
+		repo := NewMockDeviceKeysRepository()
+		uc := NewKeyDirectory(repo, NewMockUserBlockRepository(), &MockEventBus{}, NewMockRateLimiter(2))
+		_, err := uc.PublishDeviceKeys(ctx, userID, testPublishRequest(testKey("a"), 5))
+		require.NoError(t, err)
+
+		for i := 0; i < 2; i++ {
+			_, err = uc.ClaimPrekeyBundle(ctx, requesterID, userID, 1)
+			require.NoError(t, err)
+		}
+		_, err = uc.ClaimPrekeyBundle(ctx, requesterID, userID, 1)
+		assert.ErrorIs(t, err, errors.ErrRateLimitExceeded)
+
+		count, err := uc.CountOneTimePrekeys(ctx, userID, 1)
+		require.NoError(t, err)
+		assert.Equal(t, 3, count)
+
+		_, err = uc.ClaimPrekeyBundle(ctx, uuid.New(), userID, 1)
+		assert.NoError(t, err)
+	})
+
+	t.Run("blocked requester", func(t *testing.T) {
+		blocks := NewMockUserBlockRepository()
+		uc := NewKeyDirectory(NewMockDeviceKeysRepository(), blocks, &MockEventBus{}, NewMockRateLimiter(100))
+		_, err := uc.PublishDeviceKeys(ctx, userID, testPublishRequest(testKey("a"), 1))
+		require.NoError(t, err)
+		require.NoError(t, blocks.Create(ctx, entities.NewUserBlock(userID, requesterID)))
+
+		_, err = uc.ClaimPrekeyBundle(ctx, requesterID, userID, 1)
+		assert.ErrorIs(t, err, errors.ErrNotFound)
+		_, err = uc.ListDevices(ctx, requesterID, userID)
+		assert.ErrorIs(t, err, errors.ErrNotFound)
+
+		count, err := uc.CountOneTimePrekeys(ctx, userID, 1)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+}
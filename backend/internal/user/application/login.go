@@ -3,6 +3,7 @@ package application
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"golang.org/x/crypto/bcrypt"
@@ -16,6 +17,7 @@ import (
 // LoginRequest represents the request to log in a user.
 type LoginRequest struct {
 	Email    string
+	Handle   string
 	Password string
 }
 
@@ -44,8 +46,14 @@ func NewLogin(userRepo repositories.UserRepository, tokenService services.TokenS
 
 // Execute handles the user login process.
 func (uc *Login) Execute(ctx context.Context, req LoginRequest) (*LoginResponse, error) {
-	// Retrieve the user by email
-	user, err := uc.UserRepository.FindByEmail(ctx, req.Email)
+	// Retrieve the user by email, or by handle when no email is given
+	var user *entities.User
+	var err error
+	if req.Email == "" && req.Handle != "" {
+		user, err = uc.UserRepository.FindByHandle(ctx, strings.TrimPrefix(strings.TrimSpace(req.Handle), "@"))
+	} else {
+		user, err = uc.UserRepository.FindByEmail(ctx, req.Email)
+	}
 	if err != nil {
 		return nil, errors.ErrInvalidCredentials
 	}
@@ -60,6 +68,11 @@ func (uc *Login) Execute(ctx context.Context, req LoginRequest) (*LoginResponse,
 		return nil, errors.ErrEmailNotVerified
 	}
 
+	// Suspended users cannot log in until the suspension ends
+	if user.IsSuspended() {
+		return nil, errors.ErrAccountSuspended
+	}
+
 	// Generate access token
 	accessToken, err := uc.TokenService.GenerateAccessToken(user.ID.String())
 	if err != nil {
@@ -80,7 +93,7 @@ func (uc *Login) Execute(ctx context.Context, req LoginRequest) (*LoginResponse,
 
 	// Update user's last login timestamp
 	user.UpdateLastLogin()
-	if err := uc.UserRepository.Update(ctx, user); err != nil {
+	if err := uc.UserRepository.UpdateLastLogin(ctx, user.ID, *user.LastLoginAt); err != nil {
 		return nil, fmt.Errorf("failed to update user last login: %w", err)
 	}
 
@@ -0,0 +1,257 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/jefersonprimer/chatear/backend/domain/entities"
+	"github.com/jefersonprimer/chatear/backend/domain/repositories"
+	"github.com/jefersonprimer/chatear/backend/pkg/validator"
+	"github.com/jefersonprimer/chatear/backend/shared/constants"
+	"github.com/jefersonprimer/chatear/backend/shared/errors"
+	"github.com/jefersonprimer/chatear/backend/shared/events"
+)
+
+// ReportUserRequest represents a user's report against another user.
+type ReportUserRequest struct {
+	TargetUserID uuid.UUID `validate:"required"`
+	Category     string    `validate:"required,oneof=spam harassment hate_speech impersonation inappropriate_content other"`
+	Details      *string   `validate:"omitempty,max=1000"`
+}
+
+// ResolveReportRequest represents a moderator's decision on a claimed report.
+type ResolveReportRequest struct {
+	ReportID    uuid.UUID `validate:"required"`
+	Action      string    `validate:"required,oneof=dismiss warn suspend"`
+	Note        *string   `validate:"omitempty,max=1000"`
+	SuspendDays *int      `validate:"omitempty,min=1"`
+}
+
+// Moderation is the use case for abuse reports and the moderation queue.
+type Moderation struct {
+	ReportRepository repositories.ReportRepository
+	UserRepository   repositories.UserRepository
+	EventBus         repositories.EventBus
+	Validator        *validator.Validator
+}
+
+// NewModeration creates a new Moderation use case.
+func NewModeration(
+	reportRepo repositories.ReportRepository,
+	userRepo repositories.UserRepository,
+	eventBus repositories.EventBus,
+	validator *validator.Validator,
+) *Moderation {
+	return &Moderation{
+		ReportRepository: reportRepo,
+		UserRepository:   userRepo,
+		EventBus:         eventBus,
+		Validator:        validator,
+	}
+}
+
+// ReportUser files a report against another user, snapshotting their profile as it is now.
+func (uc *Moderation) ReportUser(ctx context.Context, reporterID uuid.UUID, req ReportUserRequest) (*entities.Report, error) {
+	if err := uc.Validator.Validate(req); err != nil {
+		return nil, fmt.Errorf("invalid input: %v", err)
+	}
+	if req.TargetUserID == reporterID {
+		return nil, errors.ErrCannotReportSelf
+	}
+
+	target, err := uc.UserRepository.FindByID(ctx, req.TargetUserID)
+	if err == pgx.ErrNoRows || (err == nil && target.IsDeleted) {
+		return nil, errors.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	pending, err := uc.ReportRepository.HasPendingReport(ctx, reporterID, target.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for pending reports: %w", err)
+	}
+	if pending {
+		return nil, errors.ErrReportAlreadyFiled
+	}
+
+	report := entities.NewUserReport(reporterID, target, req.Category, req.Details)
+	if err := uc.ReportRepository.Create(ctx, report); err != nil {
+		return nil, fmt.Errorf("failed to create report: %w", err)
+	}
+
+	return report, nil
+}
+
+// ListReports returns the moderation queue, oldest reports first.
+func (uc *Moderation) ListReports(ctx context.Context, moderatorID uuid.UUID, filter entities.ReportFilter, limit, offset int) ([]*entities.Report, error) {
+	if _, err := uc.requireModerator(ctx, moderatorID); err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = constants.DefaultModerationQueueLimit
+	}
+	if limit > constants.MaxModerationQueueLimit {
+		limit = constants.MaxModerationQueueLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	return uc.ReportRepository.List(ctx, filter, limit, offset)
+}
+
+// ClaimReport assigns an open report to the calling moderator. Moderators cannot claim reports against themselves.
+func (uc *Moderation) ClaimReport(ctx context.Context, moderatorID, reportID uuid.UUID) (*entities.Report, error) {
+	if _, err := uc.requireModerator(ctx, moderatorID); err != nil {
+		return nil, err
+	}
+
+	report, err := uc.findReport(ctx, reportID)
+	if err != nil {
+		return nil, err
+	}
+	if report.TargetUserID == moderatorID {
+		return nil, errors.ErrCannotModerateSelf
+	}
+
+	claimed, err := uc.ReportRepository.Claim(ctx, reportID, moderatorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim report: %w", err)
+	}
+	if !claimed {
+		return nil, errors.ErrReportNotClaimable
+	}
+
+	return uc.findReport(ctx, reportID)
+}
+
+// ResolveReport applies the moderator's action to a report they claimed and tells the reporter.
+func (uc *Moderation) ResolveReport(ctx context.Context, moderatorID uuid.UUID, req ResolveReportRequest) (*entities.Report, error) {
+	if err := uc.Validator.Validate(req); err != nil {
+		return nil, fmt.Errorf("invalid input: %v", err)
+	}
+	if _, err := uc.requireModerator(ctx, moderatorID); err != nil {
+		return nil, err
+	}
+
+	report, err := uc.findReport(ctx, req.ReportID)
+	if err != nil {
+		return nil, err
+	}
+	if report.TargetUserID == moderatorID {
+		return nil, errors.ErrCannotModerateSelf
+	}
+	if report.Status != entities.ReportStatusClaimed || report.ClaimedBy == nil || *report.ClaimedBy != moderatorID {
+		return nil, errors.ErrReportNotClaimed
+	}
+
+	target, err := uc.UserRepository.FindByID(ctx, report.TargetUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	var suspendUntil *time.Time
+	if req.Action == entities.ModerationActionSuspend {
+		days := constants.DefaultSuspensionDays
+		if req.SuspendDays != nil {
+			days = min(*req.SuspendDays, constants.MaxSuspensionDays)
+		}
+		until := time.Now().Add(time.Duration(days) * 24 * time.Hour)
+		suspendUntil = &until
+	}
+
+	// The resolution and the suspension are stored together, so a double submit or a second moderator
+	// cannot apply the action twice and a failed suspension leaves the report claimed for a retry
+	report.Resolve(moderatorID, req.Action, req.Note)
+	resolved, err := uc.ReportRepository.Resolve(ctx, report, suspendUntil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve report: %w", err)
+	}
+	if !resolved {
+		return nil, errors.ErrReportNotClaimed
+	}
+
+	note := ""
+	if req.Note != nil {
+		note = *req.Note
+	}
+
+	switch req.Action {
+	case entities.ModerationActionWarn:
+		warnedEvent := events.UserWarnedEvent{
+			UserID:    target.ID.String(),
+			Email:     target.Email,
+			Name:      target.Name,
+			Category:  report.Category,
+			Note:      note,
+			Timestamp: time.Now(),
+		}
+		if err := uc.EventBus.Publish(ctx, events.UserWarnedSubject, warnedEvent); err != nil {
+			// Log the error but don't return it, as the report is already resolved
+			fmt.Printf("failed to publish UserWarnedEvent for user %s: %v\n", target.ID.String(), err)
+		}
+	case entities.ModerationActionSuspend:
+		target.Suspend(*suspendUntil)
+		suspendedEvent := events.UserSuspendedEvent{
+			UserID:    target.ID.String(),
+			Email:     target.Email,
+			Name:      target.Name,
+			Until:     *suspendUntil,
+			Note:      note,
+			Timestamp: time.Now(),
+		}
+		if err := uc.EventBus.Publish(ctx, events.UserSuspendedSubject, suspendedEvent); err != nil {
+			// Log the error but don't return it, as the suspension is already in effect
+			fmt.Printf("failed to publish UserSuspendedEvent for user %s: %v\n", target.ID.String(), err)
+		}
+	}
+
+	reporter, err := uc.UserRepository.FindByID(ctx, report.ReporterID)
+	if err != nil {
+		// Log the error but don't return it, as the report was resolved
+		fmt.Printf("failed to load reporter of report %s: %v\n", report.ID.String(), err)
+		return report, nil
+	}
+
+	resolvedEvent := events.ReportResolvedEvent{
+		ReportID:  report.ID.String(),
+		UserID:    reporter.ID.String(),
+		Email:     reporter.Email,
+		Name:      reporter.Name,
+		Action:    req.Action,
+		Timestamp: time.Now(),
+	}
+	if err := uc.EventBus.Publish(ctx, events.ReportResolvedSubject, resolvedEvent); err != nil {
+		fmt.Printf("failed to publish ReportResolvedEvent for report %s: %v\n", report.ID.String(), err)
+	}
+
+	return report, nil
+}
+
+func (uc *Moderation) requireModerator(ctx context.Context, userID uuid.UUID) (*entities.User, error) {
+	user, err := uc.UserRepository.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsModerator() {
+		return nil, errors.ErrForbidden
+	}
+	return user, nil
+}
+
+func (uc *Moderation) findReport(ctx context.Context, reportID uuid.UUID) (*entities.Report, error) {
+	report, err := uc.ReportRepository.FindByID(ctx, reportID)
+	if err == pgx.ErrNoRows {
+		return nil, errors.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
@@ -0,0 +1,276 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jefersonprimer/chatear/backend/domain/entities"
+	"github.com/jefersonprimer/chatear/backend/pkg/validator"
+	"github.com/jefersonprimer/chatear/backend/shared/errors"
+	"github.com/jefersonprimer/chatear/backend/shared/events"
+)
+
+// MockUserRepository is an in-memory implementation of repositories.UserRepository
+type MockUserRepository struct {
+	users map[uuid.UUID]*entities.User
+}
+
+func NewMockUserRepository(users ...*entities.User) *MockUserRepository {
+	m := &MockUserRepository{users: map[uuid.UUID]*entities.User{}}
+	for _, user := range users {
+		m.users[user.ID] = user
+	}
+	return m
+}
+
+func (m *MockUserRepository) Create(ctx context.Context, user *entities.User) error {
+	m.users[user.ID] = user
+	return nil
+}
+
+func (m *MockUserRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.User, error) {
+	user, ok := m.users[id]
+	if !ok {
+		return nil, pgx.ErrNoRows
+	}
+	return user, nil
+}
+
+func (m *MockUserRepository) FindByEmail(ctx context.Context, email string) (*entities.User, error) {
+	for _, user := range m.users {
+		if user.Email == email {
+			return user, nil
+		}
+	}
+	return nil, pgx.ErrNoRows
+}
+
+func (m *MockUserRepository) FindByHandle(ctx context.Context, handle string) (*entities.User, error) {
+	for _, user := range m.users {
+		if user.Handle != nil && strings.EqualFold(*user.Handle, handle) {
+			return user, nil
+		}
+	}
+	return nil, pgx.ErrNoRows
+}
+
+func (m *MockUserRepository) Update(ctx context.Context, user *entities.User) error {
+	m.users[user.ID] = user
+	return nil
+}
+
+func (m *MockUserRepository) UpdateLastLogin(ctx context.Context, id uuid.UUID, at time.Time) error {
+	m.users[id].LastLoginAt = &at
+	return nil
+}
+
+func (m *MockUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	m.users[id].MarkAsDeleted()
+	return nil
+}
+
+func (m *MockUserRepository) FindSoftDeletedBefore(ctx context.Context, t time.Time) ([]*entities.User, error) {
+	return nil, nil
+}
+
+func (m *MockUserRepository) HardDelete(ctx context.Context, id uuid.UUID) error {
+	delete(m.users, id)
+	return nil
+}
+
+func (m *MockUserRepository) UpdateAvatar(ctx context.Context, id uuid.UUID, avatarURL, avatarPublicID string) error {
+	return nil
+}
+
+// MockReportRepository is an in-memory implementation of repositories.ReportRepository
+type MockReportRepository struct {
+	reports  map[uuid.UUID]*entities.Report
+	resolved map[uuid.UUID]bool
+	// suspended records the suspensions applied with a resolution
+	suspended map[uuid.UUID]time.Time
+}
+
+func (m *MockReportRepository) Create(ctx context.Context, report *entities.Report) error {
+	m.reports[report.ID] = report
+	return nil
+}
+
+func (m *MockReportRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.Report, error) {
+	report, ok := m.reports[id]
+	if !ok {
+		return nil, pgx.ErrNoRows
+	}
+	return report, nil
+}
+
+func (m *MockReportRepository) HasPendingReport(ctx context.Context, reporterID, targetUserID uuid.UUID) (bool, error) {
+	for _, report := range m.reports {
+		if report.ReporterID == reporterID && report.TargetUserID == targetUserID && report.Status != entities.ReportStatusResolved {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *MockReportRepository) List(ctx context.Context, filter entities.ReportFilter, limit, offset int) ([]*entities.Report, error) {
+	var reports []*entities.Report
+	for _, report := range m.reports {
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+func (m *MockReportRepository) Claim(ctx context.Context, id, moderatorID uuid.UUID) (bool, error) {
+	report, ok := m.reports[id]
+	if !ok || report.Status != entities.ReportStatusOpen {
+		return false, nil
+	}
+	report.Status = entities.ReportStatusClaimed
+	report.ClaimedBy = &moderatorID
+	return true, nil
+}
+
+func (m *MockReportRepository) Resolve(ctx context.Context, report *entities.Report, suspendUntil *time.Time) (bool, error) {
+	stored, ok := m.reports[report.ID]
+	if !ok || m.resolved[report.ID] || stored.ClaimedBy == nil || report.ResolvedBy == nil || *stored.ClaimedBy != *report.ResolvedBy {
+		return false, nil
+	}
+	m.resolved[report.ID] = true
+	m.reports[report.ID] = report
+	if suspendUntil != nil {
+		m.suspended[report.TargetUserID] = *suspendUntil
+	}
+	return true, nil
+}
+
+func newTestModeration(users ...*entities.User) (*Moderation, *MockEventBus, *MockReportRepository) {
+	bus := &MockEventBus{}
+	reports := &MockReportRepository{reports: map[uuid.UUID]*entities.Report{}, resolved: map[uuid.UUID]bool{}, suspended: map[uuid.UUID]time.Time{}}
+	return NewModeration(reports, NewMockUserRepository(users...), bus, validator.NewValidator()), bus, reports
+}
+
+func TestModeration_ReportUser(t *testing.T) {
+	ctx := context.Background()
+	reporter := entities.NewUser("Reporter", "reporter@example.com", "hash", "MALE")
+	target := entities.NewUser("Target", "target@example.com", "hash", "FEMALE")
+	bio := "abusive bio"
+	target.Bio = &bio
+	uc, _, _ := newTestModeration(reporter, target)
+
+	_, err := uc.ReportUser(ctx, reporter.ID, ReportUserRequest{TargetUserID: reporter.ID, Category: entities.ReportCategorySpam})
+	assert.ErrorIs(t, err, errors.ErrCannotReportSelf)
+
+	report, err := uc.ReportUser(ctx, reporter.ID, ReportUserRequest{TargetUserID: target.ID, Category: entities.ReportCategorySpam})
+	require.NoError(t, err)
+	assert.Equal(t, entities.ReportStatusOpen, report.Status)
+	assert.Equal(t, "Target", report.Snapshot["name"])
+
+	// Editing the profile afterwards does not change what moderators see
+	edited := "harmless bio"
+	target.Bio = &edited
+	assert.Equal(t, "abusive bio", *report.Snapshot["bio"].(*string))
+
+	_, err = uc.ReportUser(ctx, reporter.ID, ReportUserRequest{TargetUserID: target.ID, Category: entities.ReportCategoryHarassment})
+	assert.ErrorIs(t, err, errors.ErrReportAlreadyFiled)
+
+	_, err = uc.ReportUser(ctx, target.ID, ReportUserRequest{TargetUserID: reporter.ID, Category: "not-a-category"})
+	assert.Error(t, err)
+}
+
+func TestModeration_ClaimAndResolve(t *testing.T) {
+	ctx := context.Background()
+	reporter := entities.NewUser("Reporter", "reporter@example.com", "hash", "MALE")
+	target := entities.NewUser("Target", "target@example.com", "hash", "FEMALE")
+	moderator := entities.NewUser("Moderator", "mod@example.com", "hash", "FEMALE")
+	moderator.Role = entities.UserRoleModerator
+	uc, bus, reports := newTestModeration(reporter, target, moderator)
+
+	report, err := uc.ReportUser(ctx, reporter.ID, ReportUserRequest{TargetUserID: target.ID, Category: entities.ReportCategoryHarassment})
+	require.NoError(t, err)
+
+	_, err = uc.ClaimReport(ctx, reporter.ID, report.ID)
+	assert.ErrorIs(t, err, errors.ErrForbidden)
+
+	_, err = uc.ResolveReport(ctx, moderator.ID, ResolveReportRequest{ReportID: report.ID, Action: entities.ModerationActionDismiss})
+	assert.ErrorIs(t, err, errors.ErrReportNotClaimed)
+
+	_, err = uc.ClaimReport(ctx, moderator.ID, report.ID)
+	require.NoError(t, err)
+	_, err = uc.ClaimReport(ctx, moderator.ID, report.ID)
+	assert.ErrorIs(t, err, errors.ErrReportNotClaimable)
+
+	resolved, err := uc.ResolveReport(ctx, moderator.ID, ResolveReportRequest{ReportID: report.ID, Action: entities.ModerationActionSuspend})
+	require.NoError(t, err)
+	assert.Equal(t, entities.ReportStatusResolved, resolved.Status)
+	assert.True(t, target.IsSuspended())
+	assert.Contains(t, reports.suspended, target.ID)
+	assert.Equal(t, []string{events.UserSuspendedSubject, events.ReportResolvedSubject}, bus.Published)
+}
+
+func TestModeration_ResolveOnlyOnce(t *testing.T) {
+	ctx := context.Background()
+	reporter := entities.NewUser("Reporter", "reporter@example.com", "hash", "MALE")
+	target := entities.NewUser("Target", "target@example.com", "hash", "FEMALE")
+	moderator := entities.NewUser("Moderator", "mod@example.com", "hash", "FEMALE")
+	moderator.Role = entities.UserRoleModerator
+	uc, bus, _ := newTestModeration(reporter, target, moderator)
+
+	report, err := uc.ReportUser(ctx, reporter.ID, ReportUserRequest{TargetUserID: target.ID, Category: entities.ReportCategorySpam})
+	require.NoError(t, err)
+	_, err = uc.ClaimReport(ctx, moderator.ID, report.ID)
+	require.NoError(t, err)
+
+	_, err = uc.ResolveReport(ctx, moderator.ID, ResolveReportRequest{ReportID: report.ID, Action: entities.ModerationActionWarn})
+	require.NoError(t, err)
+
+	// A concurrent request that loaded the report while it was still claimed
+	stale := *report
+	stale.Status = entities.ReportStatusClaimed
+	uc.ReportRepository.(*MockReportRepository).reports[report.ID] = &stale
+	_, err = uc.ResolveReport(ctx, moderator.ID, ResolveReportRequest{ReportID: report.ID, Action: entities.ModerationActionWarn})
+	assert.ErrorIs(t, err, errors.ErrReportNotClaimed)
+	assert.Equal(t, []string{events.UserWarnedSubject, events.ReportResolvedSubject}, bus.Published)
+}
+
+func TestModeration_WarnSurvivesPublishFailure(t *testing.T) {
+	ctx := context.Background()
+	reporter := entities.NewUser("Reporter", "reporter@example.com", "hash", "MALE")
+	target := entities.NewUser("Target", "target@example.com", "hash", "FEMALE")
+	moderator := entities.NewUser("Moderator", "mod@example.com", "hash", "FEMALE")
+	moderator.Role = entities.UserRoleModerator
+	uc, bus, _ := newTestModeration(reporter, target, moderator)
+
+	report, err := uc.ReportUser(ctx, reporter.ID, ReportUserRequest{TargetUserID: target.ID, Category: entities.ReportCategorySpam})
+	require.NoError(t, err)
+	_, err = uc.ClaimReport(ctx, moderator.ID, report.ID)
+	require.NoError(t, err)
+
+	// The report is resolved once stored; a lost notification must not turn that into an error
+	bus.Err = fmt.Errorf("nats unavailable")
+	resolved, err := uc.ResolveReport(ctx, moderator.ID, ResolveReportRequest{ReportID: report.ID, Action: entities.ModerationActionWarn})
+	require.NoError(t, err)
+	assert.Equal(t, entities.ReportStatusResolved, resolved.Status)
+}
+
+func TestModeration_CannotModerateSelf(t *testing.T) {
+	ctx := context.Background()
+	reporter := entities.NewUser("Reporter", "reporter@example.com", "hash", "MALE")
+	moderator := entities.NewUser("Moderator", "mod@example.com", "hash", "FEMALE")
+	moderator.Role = entities.UserRoleModerator
+	uc, _, _ := newTestModeration(reporter, moderator)
+
+	report, err := uc.ReportUser(ctx, reporter.ID, ReportUserRequest{TargetUserID: moderator.ID, Category: entities.ReportCategoryHarassment})
+	require.NoError(t, err)
+
+	_, err = uc.ClaimReport(ctx, moderator.ID, report.ID)
+	assert.ErrorIs(t, err, errors.ErrCannotModerateSelf)
+	assert.Equal(t, entities.ReportStatusOpen, report.Status)
+}
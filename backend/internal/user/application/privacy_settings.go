@@ -0,0 +1,58 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/jefersonprimer/chatear/backend/domain/entities"
+	"github.com/jefersonprimer/chatear/backend/domain/repositories"
+	"github.com/jefersonprimer/chatear/backend/pkg/validator"
+)
+
+// UpdatePrivacySettingsRequest represents the privacy settings a user wants to change. Nil fields are left as they are.
+type UpdatePrivacySettingsRequest struct {
+	DMPrivacy    *string `validate:"omitempty,oneof=everyone contacts"`
+	Discoverable *bool
+}
+
+// PrivacySettings is the use case for a user's privacy settings.
+type PrivacySettings struct {
+	UserRepository repositories.UserRepository
+	Validator      *validator.Validator
+}
+
+// NewPrivacySettings creates a new PrivacySettings use case.
+func NewPrivacySettings(userRepo repositories.UserRepository, validator *validator.Validator) *PrivacySettings {
+	return &PrivacySettings{
+		UserRepository: userRepo,
+		Validator:      validator,
+	}
+}
+
+// UpdatePrivacySettings changes who may message the user and whether they show up in user search.
+func (uc *PrivacySettings) UpdatePrivacySettings(ctx context.Context, userID uuid.UUID, req UpdatePrivacySettingsRequest) (*entities.User, error) {
+	if err := uc.Validator.Validate(req); err != nil {
+		return nil, fmt.Errorf("invalid input: %v", err)
+	}
+
+	user, err := uc.UserRepository.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.DMPrivacy != nil {
+		user.DMPrivacy = *req.DMPrivacy
+	}
+	if req.Discoverable != nil {
+		user.Discoverable = *req.Discoverable
+	}
+	user.UpdatedAt = time.Now()
+
+	if err := uc.UserRepository.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to update privacy settings: %w", err)
+	}
+	return user, nil
+}
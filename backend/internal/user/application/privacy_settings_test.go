@@ -0,0 +1,34 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jefersonprimer/chatear/backend/domain/entities"
+	"github.com/jefersonprimer/chatear/backend/pkg/validator"
+)
+
+func TestPrivacySettings_UpdatePrivacySettings(t *testing.T) {
+	ctx := context.Background()
+	user := entities.NewUser("Alice", "alice@example.com", "hash", "FEMALE")
+	uc := NewPrivacySettings(NewMockUserRepository(user), validator.NewValidator())
+
+	invalid := "nobody"
+	_, err := uc.UpdatePrivacySettings(ctx, user.ID, UpdatePrivacySettingsRequest{DMPrivacy: &invalid})
+	assert.Error(t, err)
+
+	contacts := entities.DMPrivacyContacts
+	updated, err := uc.UpdatePrivacySettings(ctx, user.ID, UpdatePrivacySettingsRequest{DMPrivacy: &contacts})
+	require.NoError(t, err)
+	assert.Equal(t, entities.DMPrivacyContacts, updated.DMPrivacy)
+	assert.True(t, updated.Discoverable)
+
+	hidden := false
+	updated, err = uc.UpdatePrivacySettings(ctx, user.ID, UpdatePrivacySettingsRequest{Discoverable: &hidden})
+	require.NoError(t, err)
+	assert.False(t, updated.Discoverable)
+	assert.Equal(t, entities.DMPrivacyContacts, updated.DMPrivacy)
+}
@@ -0,0 +1,209 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/jefersonprimer/chatear/backend/domain/entities"
+	"github.com/jefersonprimer/chatear/backend/domain/repositories"
+	"github.com/jefersonprimer/chatear/backend/pkg/validator"
+	"github.com/jefersonprimer/chatear/backend/shared/constants"
+	"github.com/jefersonprimer/chatear/backend/shared/errors"
+)
+
+var handlePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]{2,29}$`)
+
+// reservedHandles cannot be claimed because they would impersonate the service or clash with app routes.
+var reservedHandles = map[string]bool{
+	"admin": true, "administrator": true, "root": true, "system": true, "support": true, "help": true,
+	"moderator": true, "moderation": true, "mod": true, "staff": true, "official": true, "security": true,
+	"chatear": true, "api": true, "www": true, "mail": true, "email": true, "null": true, "undefined": true,
+	"me": true, "settings": true, "explore": true, "contacts": true, "login": true, "register": true, "logout": true,
+}
+
+// UpdateProfileRequest represents the profile fields a user wants to change. Nil fields are left as they are;
+// empty strings clear them.
+type UpdateProfileRequest struct {
+	Bio        *string  `validate:"omitempty,max=300"`
+	StatusText *string  `validate:"omitempty,max=100"`
+	Pronouns   *string  `validate:"omitempty,max=40"`
+	Links      []string `validate:"omitempty,dive,url,max=200"`
+}
+
+// Profiles is the use case for handles and public profiles.
+type Profiles struct {
+	UserRepository           repositories.UserRepository
+	HandleRedirectRepository repositories.HandleRedirectRepository
+	UserBlockRepository      repositories.UserBlockRepository
+	Validator                *validator.Validator
+}
+
+// NewProfiles creates a new Profiles use case.
+func NewProfiles(
+	userRepo repositories.UserRepository,
+	handleRedirectRepo repositories.HandleRedirectRepository,
+	userBlockRepo repositories.UserBlockRepository,
+	validator *validator.Validator,
+) *Profiles {
+	return &Profiles{
+		UserRepository:           userRepo,
+		HandleRedirectRepository: handleRedirectRepo,
+		UserBlockRepository:      userBlockRepo,
+		Validator:                validator,
+	}
+}
+
+// ChangeHandle claims a handle for the user. Handles can be changed once per cooldown period; the old
+// handle keeps redirecting to the user, and stays reserved for them, for a grace period.
+func (uc *Profiles) ChangeHandle(ctx context.Context, userID uuid.UUID, handle string) (*entities.User, error) {
+	handle = strings.TrimPrefix(strings.TrimSpace(handle), "@")
+	if !handlePattern.MatchString(handle) {
+		return nil, errors.ErrInvalidHandle
+	}
+	if reservedHandles[strings.ToLower(handle)] {
+		return nil, errors.ErrHandleReserved
+	}
+
+	user, err := uc.UserRepository.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	oldHandle := user.Handle
+	// Changing only the case of the current handle does not count as a change
+	caseOnly := oldHandle != nil && strings.EqualFold(*oldHandle, handle)
+	if !caseOnly {
+		if user.HandleChangedAt != nil && time.Since(*user.HandleChangedAt) < constants.HandleChangeCooldown {
+			return nil, errors.ErrHandleChangeTooSoon
+		}
+		if err := uc.ensureHandleAvailable(ctx, user.ID, handle); err != nil {
+			return nil, err
+		}
+	}
+
+	changedAt := user.HandleChangedAt
+	user.ChangeHandle(handle)
+	if caseOnly {
+		user.HandleChangedAt = changedAt
+	}
+
+	// Reclaiming one of your own old handles ends its redirect, and the released handle keeps one
+	var redirect *entities.HandleRedirect
+	if oldHandle != nil && !caseOnly {
+		redirect = entities.NewHandleRedirect(*oldHandle, user.ID, constants.HandleRedirectGracePeriod)
+	}
+	err = uc.HandleRedirectRepository.ChangeHandle(ctx, user, redirect)
+	if err == errors.ErrHandleTaken {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to change handle: %w", err)
+	}
+
+	return user, nil
+}
+
+// UpdateProfile changes the public profile fields of a user.
+func (uc *Profiles) UpdateProfile(ctx context.Context, userID uuid.UUID, req UpdateProfileRequest) (*entities.User, error) {
+	if err := uc.Validator.Validate(req); err != nil {
+		return nil, fmt.Errorf("invalid input: %v", err)
+	}
+	if len(req.Links) > constants.MaxProfileLinks {
+		return nil, fmt.Errorf("invalid input: a profile can have at most %d links", constants.MaxProfileLinks)
+	}
+	for _, link := range req.Links {
+		if !strings.HasPrefix(link, "https://") && !strings.HasPrefix(link, "http://") {
+			return nil, fmt.Errorf("invalid input: links must use http or https")
+		}
+	}
+
+	user, err := uc.UserRepository.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Bio = optionalText(req.Bio, user.Bio)
+	user.StatusText = optionalText(req.StatusText, user.StatusText)
+	user.Pronouns = optionalText(req.Pronouns, user.Pronouns)
+	if req.Links != nil {
+		user.Links = req.Links
+	}
+	user.UpdatedAt = time.Now()
+
+	if err := uc.UserRepository.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to update profile: %w", err)
+	}
+	return user, nil
+}
+
+// FindProfileByHandle returns the public profile behind a handle, following redirects from recently
+// released handles. Deleted users and users who blocked the viewer are reported as not found.
+func (uc *Profiles) FindProfileByHandle(ctx context.Context, viewerID uuid.UUID, handle string) (*entities.PublicProfile, error) {
+	handle = strings.TrimPrefix(strings.TrimSpace(handle), "@")
+
+	user, err := uc.UserRepository.FindByHandle(ctx, handle)
+	if err == pgx.ErrNoRows {
+		redirect, redirectErr := uc.HandleRedirectRepository.FindActive(ctx, handle)
+		if redirectErr == pgx.ErrNoRows {
+			return nil, errors.ErrUserNotFound
+		}
+		if redirectErr != nil {
+			return nil, redirectErr
+		}
+		user, err = uc.UserRepository.FindByID(ctx, redirect.UserID)
+	}
+	if err == pgx.ErrNoRows || (err == nil && user.IsDeleted) {
+		return nil, errors.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = ensureNotBlocked(ctx, uc.UserBlockRepository, user.ID, viewerID)
+	if err == errors.ErrNotFound {
+		return nil, errors.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return entities.NewPublicProfile(user), nil
+}
+
+// ensureHandleAvailable fails when another user holds the handle or it still redirects to another user.
+func (uc *Profiles) ensureHandleAvailable(ctx context.Context, userID uuid.UUID, handle string) error {
+	owner, err := uc.UserRepository.FindByHandle(ctx, handle)
+	if err == nil && owner.ID != userID {
+		return errors.ErrHandleTaken
+	}
+	if err != nil && err != pgx.ErrNoRows {
+		return err
+	}
+
+	redirect, err := uc.HandleRedirectRepository.FindActive(ctx, handle)
+	if err == nil && redirect.UserID != userID {
+		return errors.ErrHandleTaken
+	}
+	if err != nil && err != pgx.ErrNoRows {
+		return err
+	}
+	return nil
+}
+
+// optionalText applies an optional text update: nil keeps the current value and an empty string clears it.
+func optionalText(value, current *string) *string {
+	if value == nil {
+		return current
+	}
+	trimmed := strings.TrimSpace(*value)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
@@ -0,0 +1,156 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jefersonprimer/chatear/backend/domain/entities"
+	"github.com/jefersonprimer/chatear/backend/pkg/validator"
+	"github.com/jefersonprimer/chatear/backend/shared/constants"
+	"github.com/jefersonprimer/chatear/backend/shared/errors"
+)
+
+// MockHandleRedirectRepository is an in-memory implementation of repositories.HandleRedirectRepository
+type MockHandleRedirectRepository struct {
+	redirects map[string]*entities.HandleRedirect
+	// changeErr simulates a failed handle change, such as losing a race for the handle
+	changeErr error
+}
+
+func NewMockHandleRedirectRepository() *MockHandleRedirectRepository {
+	return &MockHandleRedirectRepository{redirects: map[string]*entities.HandleRedirect{}}
+}
+
+func (m *MockHandleRedirectRepository) Save(ctx context.Context, redirect *entities.HandleRedirect) error {
+	m.redirects[strings.ToLower(redirect.Handle)] = redirect
+	return nil
+}
+
+func (m *MockHandleRedirectRepository) FindActive(ctx context.Context, handle string) (*entities.HandleRedirect, error) {
+	redirect, ok := m.redirects[strings.ToLower(handle)]
+	if !ok || time.Now().After(redirect.ExpiresAt) {
+		return nil, pgx.ErrNoRows
+	}
+	return redirect, nil
+}
+
+func (m *MockHandleRedirectRepository) Delete(ctx context.Context, handle string) error {
+	delete(m.redirects, strings.ToLower(handle))
+	return nil
+}
+
+func (m *MockHandleRedirectRepository) ChangeHandle(ctx context.Context, user *entities.User, redirect *entities.HandleRedirect) error {
+	if m.changeErr != nil {
+		return m.changeErr
+	}
+	delete(m.redirects, strings.ToLower(*user.Handle))
+	if redirect != nil {
+		m.redirects[strings.ToLower(redirect.Handle)] = redirect
+	}
+	return nil
+}
+
+func newTestProfiles(users ...*entities.User) (*Profiles, *MockUserBlockRepository) {
+	blocks := NewMockUserBlockRepository()
+	return NewProfiles(NewMockUserRepository(users...), NewMockHandleRedirectRepository(), blocks, validator.NewValidator()), blocks
+}
+
+func TestProfiles_ChangeHandle(t *testing.T) {
+	ctx := context.Background()
+	alice := entities.NewUser("Alice", "alice@example.com", "hash", "FEMALE")
+	bob := entities.NewUser("Bob", "bob@example.com", "hash", "MALE")
+	uc, _ := newTestProfiles(alice, bob)
+
+	for _, handle := range []string{"ab", "1alice", "ali ce", strings.Repeat("a", 31)} {
+		_, err := uc.ChangeHandle(ctx, alice.ID, handle)
+		assert.ErrorIs(t, err, errors.ErrInvalidHandle, handle)
+	}
+	_, err := uc.ChangeHandle(ctx, alice.ID, "Admin")
+	assert.ErrorIs(t, err, errors.ErrHandleReserved)
+
+	user, err := uc.ChangeHandle(ctx, alice.ID, "@Alice")
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", *user.Handle)
+
+	_, err = uc.ChangeHandle(ctx, bob.ID, "alice")
+	assert.ErrorIs(t, err, errors.ErrHandleTaken)
+
+	// Changing only the case is always allowed
+	_, err = uc.ChangeHandle(ctx, alice.ID, "ALICE")
+	require.NoError(t, err)
+
+	_, err = uc.ChangeHandle(ctx, alice.ID, "alice_w")
+	assert.ErrorIs(t, err, errors.ErrHandleChangeTooSoon)
+
+	changedAt := time.Now().Add(-constants.HandleChangeCooldown - time.Hour)
+	alice.HandleChangedAt = &changedAt
+	_, err = uc.ChangeHandle(ctx, alice.ID, "alice_w")
+	require.NoError(t, err)
+
+	// The old handle redirects to Alice and cannot be taken by someone else yet
+	profile, err := uc.FindProfileByHandle(ctx, bob.ID, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, alice.ID, profile.ID)
+	assert.Equal(t, "alice_w", *profile.Handle)
+
+	_, err = uc.ChangeHandle(ctx, bob.ID, "Alice")
+	assert.ErrorIs(t, err, errors.ErrHandleTaken)
+}
+
+func TestProfiles_ChangeHandleLosesRace(t *testing.T) {
+	ctx := context.Background()
+	alice := entities.NewUser("Alice", "alice@example.com", "hash", "FEMALE")
+	uc, _ := newTestProfiles(alice)
+	uc.HandleRedirectRepository.(*MockHandleRedirectRepository).changeErr = errors.ErrHandleTaken
+
+	_, err := uc.ChangeHandle(ctx, alice.ID, "alice")
+	assert.Equal(t, errors.ErrHandleTaken, err)
+}
+
+func TestProfiles_FindProfileByHandle(t *testing.T) {
+	ctx := context.Background()
+	alice := entities.NewUser("Alice", "alice@example.com", "hash", "FEMALE")
+	bob := entities.NewUser("Bob", "bob@example.com", "hash", "MALE")
+	uc, blocks := newTestProfiles(alice, bob)
+
+	_, err := uc.ChangeHandle(ctx, alice.ID, "alice")
+	require.NoError(t, err)
+
+	_, err = uc.FindProfileByHandle(ctx, bob.ID, "nobody")
+	assert.ErrorIs(t, err, errors.ErrUserNotFound)
+
+	require.NoError(t, blocks.Create(ctx, entities.NewUserBlock(alice.ID, bob.ID)))
+	_, err = uc.FindProfileByHandle(ctx, bob.ID, "alice")
+	assert.ErrorIs(t, err, errors.ErrUserNotFound)
+}
+
+func TestProfiles_UpdateProfile(t *testing.T) {
+	ctx := context.Background()
+	alice := entities.NewUser("Alice", "alice@example.com", "hash", "FEMALE")
+	uc, _ := newTestProfiles(alice)
+
+	_, err := uc.UpdateProfile(ctx, alice.ID, UpdateProfileRequest{Links: []string{"javascript:alert(1)"}})
+	assert.Error(t, err)
+	_, err = uc.UpdateProfile(ctx, alice.ID, UpdateProfileRequest{Links: make([]string, constants.MaxProfileLinks+1)})
+	assert.Error(t, err)
+
+	bio := "  Hello there  "
+	pronouns := "she/her"
+	user, err := uc.UpdateProfile(ctx, alice.ID, UpdateProfileRequest{Bio: &bio, Pronouns: &pronouns, Links: []string{"https://example.com"}})
+	require.NoError(t, err)
+	assert.Equal(t, "Hello there", *user.Bio)
+	assert.Equal(t, []string{"https://example.com"}, user.Links)
+
+	empty := ""
+	user, err = uc.UpdateProfile(ctx, alice.ID, UpdateProfileRequest{Bio: &empty})
+	require.NoError(t, err)
+	assert.Nil(t, user.Bio)
+	assert.Equal(t, "she/her", *user.Pronouns)
+	assert.Equal(t, []string{"https://example.com"}, user.Links)
+}
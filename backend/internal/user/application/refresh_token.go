@@ -5,6 +5,7 @@ import (
 
 	"github.com/jefersonprimer/chatear/backend/domain/repositories"
 	"github.com/jefersonprimer/chatear/backend/domain/services"
+	"github.com/jefersonprimer/chatear/backend/shared/errors"
 )
 
 // RefreshToken is a use case for refreshing a token.
@@ -35,6 +36,10 @@ func (uc *RefreshToken) Execute(ctx context.Context, token string) (*LoginRespon
 		return nil, err
 	}
 
+	if user.IsSuspended() {
+		return nil, errors.ErrAccountSuspended
+	}
+
 	accessToken, err := uc.TokenService.GenerateAccessToken(user.ID.String())
 	if err != nil {
 		return nil, err
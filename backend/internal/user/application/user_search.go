@@ -0,0 +1,118 @@
+package application
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/jefersonprimer/chatear/backend/domain/entities"
+	"github.com/jefersonprimer/chatear/backend/domain/repositories"
+	"github.com/jefersonprimer/chatear/backend/shared/constants"
+	"github.com/jefersonprimer/chatear/backend/shared/errors"
+)
+
+const maxUserSearchQueryLength = 100
+
+// UserSearchHit is a search result along with the cursor pointing at it.
+type UserSearchHit struct {
+	Profile *entities.PublicProfile
+	Cursor  string
+}
+
+// UserSearchPage is one page of search results.
+type UserSearchPage struct {
+	Hits        []*UserSearchHit
+	HasNextPage bool
+}
+
+// UserSearch is the use case for finding other users on the Explore page.
+type UserSearch struct {
+	UserSearchRepository repositories.UserSearchRepository
+}
+
+// NewUserSearch creates a new UserSearch use case.
+func NewUserSearch(userSearchRepo repositories.UserSearchRepository) *UserSearch {
+	return &UserSearch{
+		UserSearchRepository: userSearchRepo,
+	}
+}
+
+// SearchUsers returns up to first users matching the query, starting after the given cursor.
+func (uc *UserSearch) SearchUsers(ctx context.Context, viewerID uuid.UUID, query string, first int, after *string) (*UserSearchPage, error) {
+	// "@handle" searches for the handle
+	query = strings.TrimPrefix(strings.TrimSpace(query), "@")
+	if query == "" || utf8.RuneCountInString(query) > maxUserSearchQueryLength {
+		return nil, fmt.Errorf("invalid input: query must be between 1 and %d characters", maxUserSearchQueryLength)
+	}
+
+	if first <= 0 {
+		first = constants.DefaultUserSearchLimit
+	}
+	if first > constants.MaxUserSearchLimit {
+		first = constants.MaxUserSearchLimit
+	}
+
+	var cursor *entities.UserSearchCursor
+	if after != nil {
+		decoded, err := decodeUserSearchCursor(*after)
+		if err != nil {
+			return nil, err
+		}
+		cursor = decoded
+	}
+
+	// Fetch one extra result to know whether there is another page
+	results, err := uc.UserSearchRepository.Search(ctx, viewerID, query, first+1, cursor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search users: %w", err)
+	}
+
+	page := &UserSearchPage{HasNextPage: len(results) > first}
+	if page.HasNextPage {
+		results = results[:first]
+	}
+	page.Hits = make([]*UserSearchHit, 0, len(results))
+	for _, result := range results {
+		page.Hits = append(page.Hits, &UserSearchHit{
+			Profile: result.Profile,
+			Cursor:  encodeUserSearchCursor(result),
+		})
+	}
+	return page, nil
+}
+
+func encodeUserSearchCursor(result *entities.UserSearchResult) string {
+	raw := fmt.Sprintf("%t:%s:%s", result.PrefixMatch, strconv.FormatFloat(float64(result.Score), 'g', -1, 32), result.Profile.ID.String())
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeUserSearchCursor(cursor string) (*entities.UserSearchCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, errors.ErrInvalidCursor
+	}
+
+	parts := strings.Split(string(raw), ":")
+	if len(parts) != 3 {
+		return nil, errors.ErrInvalidCursor
+	}
+	prefixMatch, err := strconv.ParseBool(parts[0])
+	if err != nil {
+		return nil, errors.ErrInvalidCursor
+	}
+	score, err := strconv.ParseFloat(parts[1], 32)
+	if err != nil {
+		return nil, errors.ErrInvalidCursor
+	}
+	userID, err := uuid.Parse(parts[2])
+	if err != nil {
+		return nil, errors.ErrInvalidCursor
+	}
+
+	return &entities.UserSearchCursor{PrefixMatch: prefixMatch, Score: float32(score), UserID: userID}, nil
+}
@@ -0,0 +1,68 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jefersonprimer/chatear/backend/domain/entities"
+	"github.com/jefersonprimer/chatear/backend/shared/errors"
+)
+
+// MockUserSearchRepository pages through a fixed, already ranked result list
+type MockUserSearchRepository struct {
+	results []*entities.UserSearchResult
+	after   *entities.UserSearchCursor
+}
+
+func (m *MockUserSearchRepository) Search(ctx context.Context, viewerID uuid.UUID, query string, limit int, after *entities.UserSearchCursor) ([]*entities.UserSearchResult, error) {
+	m.after = after
+	start := 0
+	if after != nil {
+		for i, result := range m.results {
+			if result.Profile.ID == after.UserID {
+				start = i + 1
+			}
+		}
+	}
+	end := min(start+limit, len(m.results))
+	return m.results[start:end], nil
+}
+
+func TestUserSearch_SearchUsers(t *testing.T) {
+	ctx := context.Background()
+	repo := &MockUserSearchRepository{}
+	for i, score := range []float32{0.9, 0.5, 0.123456789} {
+		repo.results = append(repo.results, &entities.UserSearchResult{
+			Profile:     &entities.PublicProfile{ID: uuid.New(), Name: "Ana"},
+			PrefixMatch: i == 0,
+			Score:       score,
+		})
+	}
+	uc := NewUserSearch(repo)
+
+	_, err := uc.SearchUsers(ctx, uuid.New(), "   ", 10, nil)
+	assert.Error(t, err)
+
+	first, err := uc.SearchUsers(ctx, uuid.New(), "an", 2, nil)
+	require.NoError(t, err)
+	require.Len(t, first.Hits, 2)
+	assert.True(t, first.HasNextPage)
+
+	second, err := uc.SearchUsers(ctx, uuid.New(), "an", 2, &first.Hits[1].Cursor)
+	require.NoError(t, err)
+	require.Len(t, second.Hits, 1)
+	assert.False(t, second.HasNextPage)
+	assert.Equal(t, &entities.UserSearchCursor{PrefixMatch: false, Score: 0.5, UserID: repo.results[1].Profile.ID}, repo.after)
+
+	cursor, err := decodeUserSearchCursor(second.Hits[0].Cursor)
+	require.NoError(t, err)
+	assert.Equal(t, repo.results[2].Score, cursor.Score)
+
+	bad := "not a cursor"
+	_, err = uc.SearchUsers(ctx, uuid.New(), "an", 2, &bad)
+	assert.ErrorIs(t, err, errors.ErrInvalidCursor)
+}
@@ -0,0 +1,196 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jefersonprimer/chatear/backend/domain/entities"
+	"github.com/jefersonprimer/chatear/backend/domain/repositories"
+	appErrors "github.com/jefersonprimer/chatear/backend/shared/errors"
+)
+
+const contactRequestColumns = `id, sender_id, recipient_id, status, created_at, responded_at`
+
+// PostgresContactRepository is a PostgreSQL implementation of the ContactRepository.
+type PostgresContactRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewPostgresContactRepository creates a new PostgresContactRepository.
+func NewPostgresContactRepository(db *pgxpool.Pool) repositories.ContactRepository {
+	return &PostgresContactRepository{
+		db: db,
+	}
+}
+
+func scanContactRequest(row pgx.Row) (*entities.ContactRequest, error) {
+	request := &entities.ContactRequest{}
+	err := row.Scan(&request.ID, &request.SenderID, &request.RecipientID, &request.Status, &request.CreatedAt, &request.RespondedAt)
+	if err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// queryPendingRequests loads pending requests together with both accounts, leaving out requests involving a
+// deleted account. where filters on the request, aliased cr, with the status as $1.
+func (r *PostgresContactRepository) queryPendingRequests(ctx context.Context, where string, args ...any) ([]*entities.ContactRequest, error) {
+	query := `SELECT cr.id, cr.sender_id, cr.recipient_id, cr.status, cr.created_at, cr.responded_at, ` + qualifiedUserColumns("s") + `, ` + qualifiedUserColumns("r") + `
+		FROM contact_requests cr
+		JOIN users s ON s.id = cr.sender_id
+		JOIN users r ON r.id = cr.recipient_id
+		WHERE cr.status = $1 AND ` + where + ` AND s.is_deleted = false AND r.is_deleted = false
+		ORDER BY cr.created_at DESC`
+	rows, err := r.db.Query(ctx, query, append([]any{entities.ContactRequestStatusPending}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var requests []*entities.ContactRequest
+	for rows.Next() {
+		request := &entities.ContactRequest{Sender: &entities.User{}, Recipient: &entities.User{}}
+		fields := []any{&request.ID, &request.SenderID, &request.RecipientID, &request.Status, &request.CreatedAt, &request.RespondedAt}
+		fields = append(fields, userFields(request.Sender)...)
+		fields = append(fields, userFields(request.Recipient)...)
+		if err := rows.Scan(fields...); err != nil {
+			return nil, err
+		}
+		requests = append(requests, request)
+	}
+	return requests, rows.Err()
+}
+
+// CreateRequest creates a new contact request in the database.
+// It returns ErrContactRequestExists if a pending request between the two users already exists.
+func (r *PostgresContactRepository) CreateRequest(ctx context.Context, request *entities.ContactRequest) error {
+	query := `INSERT INTO contact_requests (id, sender_id, recipient_id, status, created_at) VALUES ($1, $2, $3, $4, $5)`
+	_, err := r.db.Exec(ctx, query, request.ID, request.SenderID, request.RecipientID, request.Status, request.CreatedAt)
+
+	// Requests sent both ways at once both pass the pending check; the unique index keeps only one
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation && pgErr.ConstraintName == "idx_contact_requests_pending_pair" {
+		return appErrors.ErrContactRequestExists
+	}
+	return err
+}
+
+// FindRequestByID retrieves a contact request by its ID.
+func (r *PostgresContactRepository) FindRequestByID(ctx context.Context, id uuid.UUID) (*entities.ContactRequest, error) {
+	query := `SELECT ` + contactRequestColumns + ` FROM contact_requests WHERE id = $1`
+	return scanContactRequest(r.db.QueryRow(ctx, query, id))
+}
+
+// FindPendingRequestBetween retrieves the pending request between two users, in either direction.
+func (r *PostgresContactRepository) FindPendingRequestBetween(ctx context.Context, userID, otherUserID uuid.UUID) (*entities.ContactRequest, error) {
+	query := `SELECT ` + contactRequestColumns + ` FROM contact_requests
+		WHERE status = $3 AND ((sender_id = $1 AND recipient_id = $2) OR (sender_id = $2 AND recipient_id = $1))`
+	request, err := scanContactRequest(r.db.QueryRow(ctx, query, userID, otherUserID, entities.ContactRequestStatusPending))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	return request, err
+}
+
+// HasRequestClosedSince checks for a request from sender to recipient that was cancelled or declined after since.
+func (r *PostgresContactRepository) HasRequestClosedSince(ctx context.Context, senderID, recipientID uuid.UUID, since time.Time) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM contact_requests WHERE sender_id = $1 AND recipient_id = $2 AND status IN ($3, $4) AND responded_at > $5)`
+	var exists bool
+	err := r.db.QueryRow(ctx, query, senderID, recipientID, entities.ContactRequestStatusCancelled, entities.ContactRequestStatusDeclined, since).Scan(&exists)
+	return exists, err
+}
+
+// FindPendingRequestsByRecipientID retrieves the pending requests a user received, most recent first.
+func (r *PostgresContactRepository) FindPendingRequestsByRecipientID(ctx context.Context, recipientID uuid.UUID) ([]*entities.ContactRequest, error) {
+	return r.queryPendingRequests(ctx, `cr.recipient_id = $2`, recipientID)
+}
+
+// FindPendingRequestsBySenderID retrieves the pending requests a user sent, most recent first.
+func (r *PostgresContactRepository) FindPendingRequestsBySenderID(ctx context.Context, senderID uuid.UUID) ([]*entities.ContactRequest, error) {
+	return r.queryPendingRequests(ctx, `cr.sender_id = $2`, senderID)
+}
+
+// closeRequestQuery only touches pending requests, so an answer cannot race a concurrent cancel or decline.
+const closeRequestQuery = `UPDATE contact_requests SET status = $1, responded_at = $2 WHERE id = $3 AND status = $4`
+
+// UpdateRequest closes a pending contact request with its new status.
+// It returns ErrContactRequestClosed if the request was no longer pending.
+func (r *PostgresContactRepository) UpdateRequest(ctx context.Context, request *entities.ContactRequest) error {
+	tag, err := r.db.Exec(ctx, closeRequestQuery, request.Status, request.RespondedAt, request.ID, entities.ContactRequestStatusPending)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return appErrors.ErrContactRequestClosed
+	}
+	return nil
+}
+
+// AcceptRequest marks a pending request accepted and stores the contact relationship for both users in one transaction.
+// It returns ErrContactRequestClosed, without adding contacts, if the request was no longer pending.
+func (r *PostgresContactRepository) AcceptRequest(ctx context.Context, request *entities.ContactRequest) error {
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, closeRequestQuery, request.Status, request.RespondedAt, request.ID, entities.ContactRequestStatusPending)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return appErrors.ErrContactRequestClosed
+		}
+
+		query := `INSERT INTO contacts (user_id, contact_id, created_at) VALUES ($1, $2, $3), ($2, $1, $3) ON CONFLICT (user_id, contact_id) DO NOTHING`
+		_, err = tx.Exec(ctx, query, request.SenderID, request.RecipientID, time.Now())
+		return err
+	})
+}
+
+// CancelPendingRequestsBetween cancels pending requests between two users, in either direction.
+func (r *PostgresContactRepository) CancelPendingRequestsBetween(ctx context.Context, userID, otherUserID uuid.UUID) error {
+	query := `UPDATE contact_requests SET status = $3, responded_at = $4
+		WHERE status = $5 AND ((sender_id = $1 AND recipient_id = $2) OR (sender_id = $2 AND recipient_id = $1))`
+	_, err := r.db.Exec(ctx, query, userID, otherUserID, entities.ContactRequestStatusCancelled, time.Now(), entities.ContactRequestStatusPending)
+	return err
+}
+
+// AreContacts checks whether two users are contacts.
+func (r *PostgresContactRepository) AreContacts(ctx context.Context, userID, otherUserID uuid.UUID) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM contacts WHERE user_id = $1 AND contact_id = $2)`
+	var exists bool
+	err := r.db.QueryRow(ctx, query, userID, otherUserID).Scan(&exists)
+	return exists, err
+}
+
+// FindContactsByUserID retrieves the contacts of a user along with their accounts, most recent first.
+// Contacts whose accounts were deleted since are left out.
+func (r *PostgresContactRepository) FindContactsByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.Contact, error) {
+	query := `SELECT c.user_id, c.contact_id, c.created_at, ` + qualifiedUserColumns("u") + ` FROM contacts c
+		JOIN users u ON u.id = c.contact_id
+		WHERE c.user_id = $1 AND u.is_deleted = false ORDER BY c.created_at DESC`
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var contacts []*entities.Contact
+	for rows.Next() {
+		contact := &entities.Contact{Contact: &entities.User{}}
+		if err := rows.Scan(append([]any{&contact.UserID, &contact.ContactID, &contact.CreatedAt}, userFields(contact.Contact)...)...); err != nil {
+			return nil, err
+		}
+		contacts = append(contacts, contact)
+	}
+	return contacts, rows.Err()
+}
+
+// RemoveContact deletes the relationship for both users.
+func (r *PostgresContactRepository) RemoveContact(ctx context.Context, userID, otherUserID uuid.UUID) error {
+	query := `DELETE FROM contacts WHERE (user_id = $1 AND contact_id = $2) OR (user_id = $2 AND contact_id = $1)`
+	_, err := r.db.Exec(ctx, query, userID, otherUserID)
+	return err
+}
@@ -0,0 +1,135 @@
+package infrastructure
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jefersonprimer/chatear/backend/domain/entities"
+	"github.com/jefersonprimer/chatear/backend/domain/repositories"
+)
+
+// PostgresDeviceKeysRepository is a PostgreSQL implementation of the DeviceKeysRepository.
+type PostgresDeviceKeysRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewPostgresDeviceKeysRepository creates a new PostgresDeviceKeysRepository.
+func NewPostgresDeviceKeysRepository(db *pgxpool.Pool) repositories.DeviceKeysRepository {
+	return &PostgresDeviceKeysRepository{
+		db: db,
+	}
+}
+
+// PublishDevice creates or replaces the published keys of a device and stores its one-time prekeys in one
+// transaction, so a device never ends up with a new identity key next to prekeys of the old one.
+func (r *PostgresDeviceKeysRepository) PublishDevice(ctx context.Context, device *entities.DeviceKeys, prekeys []*entities.OneTimePrekey, replacePrekeys bool) error {
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		query := `INSERT INTO device_keys (user_id, device_id, identity_key, signed_prekey_id, signed_prekey, signed_prekey_signature, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			ON CONFLICT (user_id, device_id) DO UPDATE SET identity_key = EXCLUDED.identity_key, signed_prekey_id = EXCLUDED.signed_prekey_id,
+				signed_prekey = EXCLUDED.signed_prekey, signed_prekey_signature = EXCLUDED.signed_prekey_signature, updated_at = EXCLUDED.updated_at`
+		if _, err := tx.Exec(ctx, query, device.UserID, device.DeviceID, device.IdentityKey, device.SignedPrekeyID, device.SignedPrekey, device.SignedPrekeySignature, device.CreatedAt, device.UpdatedAt); err != nil {
+			return err
+		}
+
+		if replacePrekeys {
+			query = `DELETE FROM one_time_prekeys WHERE user_id = $1 AND device_id = $2`
+			if _, err := tx.Exec(ctx, query, device.UserID, device.DeviceID); err != nil {
+				return err
+			}
+		}
+
+		if len(prekeys) == 0 {
+			return nil
+		}
+		return tx.SendBatch(ctx, oneTimePrekeysBatch(prekeys)).Close()
+	})
+}
+
+// FindDevice retrieves the published keys of a single device.
+func (r *PostgresDeviceKeysRepository) FindDevice(ctx context.Context, userID uuid.UUID, deviceID int) (*entities.DeviceKeys, error) {
+	query := `SELECT user_id, device_id, identity_key, signed_prekey_id, signed_prekey, signed_prekey_signature, created_at, updated_at FROM device_keys WHERE user_id = $1 AND device_id = $2`
+	device := &entities.DeviceKeys{}
+	err := r.db.QueryRow(ctx, query, userID, deviceID).Scan(&device.UserID, &device.DeviceID, &device.IdentityKey, &device.SignedPrekeyID, &device.SignedPrekey, &device.SignedPrekeySignature, &device.CreatedAt, &device.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return device, nil
+}
+
+// FindDevicesByUserID retrieves the published keys of every device of a user.
+func (r *PostgresDeviceKeysRepository) FindDevicesByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.DeviceKeys, error) {
+	query := `SELECT user_id, device_id, identity_key, signed_prekey_id, signed_prekey, signed_prekey_signature, created_at, updated_at FROM device_keys WHERE user_id = $1 ORDER BY device_id`
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var devices []*entities.DeviceKeys
+	for rows.Next() {
+		device := &entities.DeviceKeys{}
+		err := rows.Scan(&device.UserID, &device.DeviceID, &device.IdentityKey, &device.SignedPrekeyID, &device.SignedPrekey, &device.SignedPrekeySignature, &device.CreatedAt, &device.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		devices = append(devices, device)
+	}
+
+	return devices, rows.Err()
+}
+
+// AddOneTimePrekeys stores a batch of one-time prekeys, ignoring key IDs that were already uploaded.
+func (r *PostgresDeviceKeysRepository) AddOneTimePrekeys(ctx context.Context, prekeys []*entities.OneTimePrekey) error {
+	return r.db.SendBatch(ctx, oneTimePrekeysBatch(prekeys)).Close()
+}
+
+func oneTimePrekeysBatch(prekeys []*entities.OneTimePrekey) *pgx.Batch {
+	query := `INSERT INTO one_time_prekeys (user_id, device_id, key_id, public_key, created_at) VALUES ($1, $2, $3, $4, $5) ON CONFLICT (user_id, device_id, key_id) DO NOTHING`
+	batch := &pgx.Batch{}
+	for _, prekey := range prekeys {
+		batch.Queue(query, prekey.UserID, prekey.DeviceID, prekey.KeyID, prekey.PublicKey, prekey.CreatedAt)
+	}
+	return batch
+}
+
+// ClaimOneTimePrekey deletes the oldest prekey of a device and returns it with the number of prekeys left.
+// Claims for the same device are serialized on the device row, so each one sees the count its own claim left behind.
+func (r *PostgresDeviceKeysRepository) ClaimOneTimePrekey(ctx context.Context, userID uuid.UUID, deviceID int) (*entities.OneTimePrekey, int, error) {
+	var prekey *entities.OneTimePrekey
+	var remaining int
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, `SELECT 1 FROM device_keys WHERE user_id = $1 AND device_id = $2 FOR UPDATE`, userID, deviceID); err != nil {
+			return err
+		}
+
+		query := `DELETE FROM one_time_prekeys WHERE (user_id, device_id, key_id) = (
+				SELECT user_id, device_id, key_id FROM one_time_prekeys WHERE user_id = $1 AND device_id = $2 ORDER BY created_at, key_id LIMIT 1
+			) RETURNING user_id, device_id, key_id, public_key, created_at`
+		claimed := &entities.OneTimePrekey{}
+		err := tx.QueryRow(ctx, query, userID, deviceID).Scan(&claimed.UserID, &claimed.DeviceID, &claimed.KeyID, &claimed.PublicKey, &claimed.CreatedAt)
+		if err == pgx.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		prekey = claimed
+
+		return tx.QueryRow(ctx, `SELECT COUNT(*) FROM one_time_prekeys WHERE user_id = $1 AND device_id = $2`, userID, deviceID).Scan(&remaining)
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return prekey, remaining, nil
+}
+
+// CountOneTimePrekeys returns how many unclaimed prekeys a device has left.
+func (r *PostgresDeviceKeysRepository) CountOneTimePrekeys(ctx context.Context, userID uuid.UUID, deviceID int) (int, error) {
+	query := `SELECT COUNT(*) FROM one_time_prekeys WHERE user_id = $1 AND device_id = $2`
+	var count int
+	err := r.db.QueryRow(ctx, query, userID, deviceID).Scan(&count)
+	return count, err
+}
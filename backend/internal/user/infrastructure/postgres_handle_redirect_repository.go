@@ -0,0 +1,84 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jefersonprimer/chatear/backend/domain/entities"
+	"github.com/jefersonprimer/chatear/backend/domain/repositories"
+	appErrors "github.com/jefersonprimer/chatear/backend/shared/errors"
+)
+
+// uniqueViolation is the PostgreSQL error code for a unique constraint violation.
+const uniqueViolation = "23505"
+
+// PostgresHandleRedirectRepository is a PostgreSQL implementation of the HandleRedirectRepository.
+type PostgresHandleRedirectRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewPostgresHandleRedirectRepository creates a new PostgresHandleRedirectRepository.
+func NewPostgresHandleRedirectRepository(db *pgxpool.Pool) repositories.HandleRedirectRepository {
+	return &PostgresHandleRedirectRepository{
+		db: db,
+	}
+}
+
+const saveRedirectQuery = `INSERT INTO handle_redirects (handle, user_id, created_at, expires_at) VALUES ($1, $2, $3, $4)
+	ON CONFLICT (lower(handle)) DO UPDATE SET handle = EXCLUDED.handle, user_id = EXCLUDED.user_id, created_at = EXCLUDED.created_at, expires_at = EXCLUDED.expires_at`
+
+// Save stores a redirect, replacing any earlier redirect for the same handle.
+func (r *PostgresHandleRedirectRepository) Save(ctx context.Context, redirect *entities.HandleRedirect) error {
+	_, err := r.db.Exec(ctx, saveRedirectQuery, redirect.Handle, redirect.UserID, redirect.CreatedAt, redirect.ExpiresAt)
+	return err
+}
+
+// FindActive retrieves the unexpired redirect for a handle, ignoring case.
+func (r *PostgresHandleRedirectRepository) FindActive(ctx context.Context, handle string) (*entities.HandleRedirect, error) {
+	query := `SELECT handle, user_id, created_at, expires_at FROM handle_redirects WHERE lower(handle) = lower($1) AND expires_at > now()`
+	redirect := &entities.HandleRedirect{}
+	err := r.db.QueryRow(ctx, query, handle).Scan(&redirect.Handle, &redirect.UserID, &redirect.CreatedAt, &redirect.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	return redirect, nil
+}
+
+// Delete removes the redirect for a handle, ignoring case.
+func (r *PostgresHandleRedirectRepository) Delete(ctx context.Context, handle string) error {
+	query := `DELETE FROM handle_redirects WHERE lower(handle) = lower($1)`
+	_, err := r.db.Exec(ctx, query, handle)
+	return err
+}
+
+// ChangeHandle stores the user's new handle, ends any redirect from it and saves the redirect from the released
+// handle in one transaction, so the old handle is never free for others to claim in between.
+func (r *PostgresHandleRedirectRepository) ChangeHandle(ctx context.Context, user *entities.User, redirect *entities.HandleRedirect) error {
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		query := `UPDATE users SET handle = $1, handle_changed_at = $2, updated_at = $3 WHERE id = $4`
+		if _, err := tx.Exec(ctx, query, user.Handle, user.HandleChangedAt, user.UpdatedAt, user.ID); err != nil {
+			return err
+		}
+
+		query = `DELETE FROM handle_redirects WHERE lower(handle) = lower($1)`
+		if _, err := tx.Exec(ctx, query, user.Handle); err != nil {
+			return err
+		}
+
+		if redirect == nil {
+			return nil
+		}
+		_, err := tx.Exec(ctx, saveRedirectQuery, redirect.Handle, redirect.UserID, redirect.CreatedAt, redirect.ExpiresAt)
+		return err
+	})
+
+	// Two users can pass the availability check at once; the unique index decides who gets the handle
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation && pgErr.ConstraintName == "idx_users_handle_lower" {
+		return appErrors.ErrHandleTaken
+	}
+	return err
+}
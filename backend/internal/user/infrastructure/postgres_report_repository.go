@@ -0,0 +1,147 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jefersonprimer/chatear/backend/domain/entities"
+	"github.com/jefersonprimer/chatear/backend/domain/repositories"
+)
+
+const reportColumns = `id, reporter_id, target_type, target_user_id, category, details, snapshot, status, claimed_by, claimed_at, resolved_by, resolved_at, action, resolution_note, created_at, updated_at`
+
+// PostgresReportRepository is a PostgreSQL implementation of the ReportRepository.
+type PostgresReportRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewPostgresReportRepository creates a new PostgresReportRepository.
+func NewPostgresReportRepository(db *pgxpool.Pool) repositories.ReportRepository {
+	return &PostgresReportRepository{
+		db: db,
+	}
+}
+
+type reportScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanReport(row reportScanner) (*entities.Report, error) {
+	report := &entities.Report{}
+	err := row.Scan(&report.ID, &report.ReporterID, &report.TargetType, &report.TargetUserID, &report.Category, &report.Details, &report.Snapshot, &report.Status, &report.ClaimedBy, &report.ClaimedAt, &report.ResolvedBy, &report.ResolvedAt, &report.Action, &report.ResolutionNote, &report.CreatedAt, &report.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// Create creates a new report in the database.
+func (r *PostgresReportRepository) Create(ctx context.Context, report *entities.Report) error {
+	query := `INSERT INTO reports (id, reporter_id, target_type, target_user_id, category, details, snapshot, status, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+	_, err := r.db.Exec(ctx, query, report.ID, report.ReporterID, report.TargetType, report.TargetUserID, report.Category, report.Details, report.Snapshot, report.Status, report.CreatedAt, report.UpdatedAt)
+	return err
+}
+
+// FindByID retrieves a report by its ID.
+func (r *PostgresReportRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.Report, error) {
+	query := `SELECT ` + reportColumns + ` FROM reports WHERE id = $1`
+	return scanReport(r.db.QueryRow(ctx, query, id))
+}
+
+// HasPendingReport checks for an unresolved report from the same reporter against the same user.
+func (r *PostgresReportRepository) HasPendingReport(ctx context.Context, reporterID, targetUserID uuid.UUID) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM reports WHERE reporter_id = $1 AND target_user_id = $2 AND status <> $3)`
+	var exists bool
+	err := r.db.QueryRow(ctx, query, reporterID, targetUserID, entities.ReportStatusResolved).Scan(&exists)
+	return exists, err
+}
+
+// List retrieves reports matching the filter, oldest first.
+func (r *PostgresReportRepository) List(ctx context.Context, filter entities.ReportFilter, limit, offset int) ([]*entities.Report, error) {
+	var conditions []string
+	var args []any
+	if filter.Status != nil {
+		args = append(args, *filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if filter.Category != nil {
+		args = append(args, *filter.Category)
+		conditions = append(conditions, fmt.Sprintf("category = $%d", len(args)))
+	}
+	if filter.ClaimedBy != nil {
+		args = append(args, *filter.ClaimedBy)
+		conditions = append(conditions, fmt.Sprintf("claimed_by = $%d", len(args)))
+	}
+
+	query := `SELECT ` + reportColumns + ` FROM reports`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	args = append(args, limit, offset)
+	query += fmt.Sprintf(` ORDER BY created_at LIMIT $%d OFFSET $%d`, len(args)-1, len(args))
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reports []*entities.Report
+	for rows.Next() {
+		report, err := scanReport(rows)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+
+	return reports, rows.Err()
+}
+
+// Claim assigns an open report to a moderator. The status check keeps two moderators from claiming the same report.
+func (r *PostgresReportRepository) Claim(ctx context.Context, id, moderatorID uuid.UUID) (bool, error) {
+	query := `UPDATE reports SET status = $1, claimed_by = $2, claimed_at = $3, updated_at = $3 WHERE id = $4 AND status = $5`
+	tag, err := r.db.Exec(ctx, query, entities.ReportStatusClaimed, moderatorID, time.Now(), id, entities.ReportStatusOpen)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// Resolve stores the resolution of a report. The status and claim checks keep a report from being resolved twice,
+// or by a moderator who did not claim it. A suspension is applied in the same transaction, so a report is never
+// left resolved as "suspend" without the user being suspended.
+func (r *PostgresReportRepository) Resolve(ctx context.Context, report *entities.Report, suspendUntil *time.Time) (bool, error) {
+	resolved := false
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		query := `UPDATE reports SET status = $1, resolved_by = $2, resolved_at = $3, action = $4, resolution_note = $5, updated_at = $6 WHERE id = $7 AND status = $8 AND claimed_by = $2`
+		tag, err := tx.Exec(ctx, query, entities.ReportStatusResolved, report.ResolvedBy, report.ResolvedAt, report.Action, report.ResolutionNote, report.UpdatedAt, report.ID, entities.ReportStatusClaimed)
+		if err != nil {
+			return err
+		}
+		resolved = tag.RowsAffected() == 1
+		if !resolved || suspendUntil == nil {
+			return nil
+		}
+
+		query = `UPDATE users SET suspended_until = $1, updated_at = $2 WHERE id = $3`
+		if _, err := tx.Exec(ctx, query, suspendUntil, report.UpdatedAt, report.TargetUserID); err != nil {
+			return err
+		}
+		// Existing sessions must not outlive the suspension
+		query = `UPDATE refresh_tokens SET revoked = true WHERE user_id = $1`
+		if _, err := tx.Exec(ctx, query, report.TargetUserID); err != nil {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return resolved, nil
+}
@@ -0,0 +1,68 @@
+package infrastructure
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jefersonprimer/chatear/backend/domain/entities"
+	"github.com/jefersonprimer/chatear/backend/domain/repositories"
+)
+
+// PostgresUserBlockRepository is a PostgreSQL implementation of the UserBlockRepository.
+type PostgresUserBlockRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewPostgresUserBlockRepository creates a new PostgresUserBlockRepository.
+func NewPostgresUserBlockRepository(db *pgxpool.Pool) repositories.UserBlockRepository {
+	return &PostgresUserBlockRepository{
+		db: db,
+	}
+}
+
+// Create stores a block. Blocking someone twice keeps the original block.
+func (r *PostgresUserBlockRepository) Create(ctx context.Context, block *entities.UserBlock) error {
+	query := `INSERT INTO user_blocks (blocker_id, blocked_id, created_at) VALUES ($1, $2, $3) ON CONFLICT (blocker_id, blocked_id) DO NOTHING`
+	_, err := r.db.Exec(ctx, query, block.BlockerID, block.BlockedID, block.CreatedAt)
+	return err
+}
+
+// Delete removes a block.
+func (r *PostgresUserBlockRepository) Delete(ctx context.Context, blockerID, blockedID uuid.UUID) error {
+	query := `DELETE FROM user_blocks WHERE blocker_id = $1 AND blocked_id = $2`
+	_, err := r.db.Exec(ctx, query, blockerID, blockedID)
+	return err
+}
+
+// IsBlocked checks whether blockerID has blocked blockedID.
+func (r *PostgresUserBlockRepository) IsBlocked(ctx context.Context, blockerID, blockedID uuid.UUID) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM user_blocks WHERE blocker_id = $1 AND blocked_id = $2)`
+	var blocked bool
+	err := r.db.QueryRow(ctx, query, blockerID, blockedID).Scan(&blocked)
+	return blocked, err
+}
+
+// FindByBlockerID retrieves every block made by a user along with the blocked account, most recent first.
+// Blocks of accounts that were deleted since are left out.
+func (r *PostgresUserBlockRepository) FindByBlockerID(ctx context.Context, blockerID uuid.UUID) ([]*entities.UserBlock, error) {
+	query := `SELECT b.blocker_id, b.blocked_id, b.created_at, ` + qualifiedUserColumns("u") + ` FROM user_blocks b
+		JOIN users u ON u.id = b.blocked_id
+		WHERE b.blocker_id = $1 AND u.is_deleted = false ORDER BY b.created_at DESC`
+	rows, err := r.db.Query(ctx, query, blockerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var blocks []*entities.UserBlock
+	for rows.Next() {
+		block := &entities.UserBlock{Blocked: &entities.User{}}
+		if err := rows.Scan(append([]any{&block.BlockerID, &block.BlockedID, &block.CreatedAt}, userFields(block.Blocked)...)...); err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, block)
+	}
+
+	return blocks, rows.Err()
+}
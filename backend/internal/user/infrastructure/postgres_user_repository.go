@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jefersonprimer/chatear/backend/domain/entities"
 )
@@ -33,32 +34,57 @@ func (r *PostgresUserRepository) Create(ctx context.Context, user *entities.User
 	return err
 }
 
-// FindByID retrieves a user by their ID from the database.
-func (r *PostgresUserRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.User, error) {
-	query := `SELECT id, name, email, password_hash, is_email_verified, created_at, updated_at, last_login_at, avatar_url, avatar_public_id, is_deleted, deleted_at, deletion_due_at, gender FROM users WHERE id = $1`
+const userColumns = `id, name, email, password_hash, is_email_verified, created_at, updated_at, last_login_at, avatar_url, avatar_public_id, is_deleted, deleted_at, deletion_due_at, gender, role, suspended_until, dm_privacy, discoverable, handle, handle_changed_at, bio, status_text, pronouns, links`
+
+// qualifiedUserColumns returns userColumns prefixed with a table alias, for queries that join users.
+func qualifiedUserColumns(alias string) string {
+	return alias + "." + strings.ReplaceAll(userColumns, ", ", ", "+alias+".")
+}
+
+// userFields returns the scan destinations for userColumns.
+func userFields(user *entities.User) []any {
+	return []any{&user.ID, &user.Name, &user.Email, &user.PasswordHash, &user.IsEmailVerified, &user.CreatedAt, &user.UpdatedAt, &user.LastLoginAt, &user.AvatarURL, &user.AvatarPublicID, &user.IsDeleted, &user.DeletedAt, &user.DeletionDueAt, &user.Gender, &user.Role, &user.SuspendedUntil, &user.DMPrivacy, &user.Discoverable, &user.Handle, &user.HandleChangedAt, &user.Bio, &user.StatusText, &user.Pronouns, &user.Links}
+}
+
+func scanUser(row pgx.Row) (*entities.User, error) {
 	user := &entities.User{}
-	err := r.DB.QueryRow(ctx, query, id).Scan(&user.ID, &user.Name, &user.Email, &user.PasswordHash, &user.IsEmailVerified, &user.CreatedAt, &user.UpdatedAt, &user.LastLoginAt, &user.AvatarURL, &user.AvatarPublicID, &user.IsDeleted, &user.DeletedAt, &user.DeletionDueAt, &user.Gender)
+	err := row.Scan(userFields(user)...)
 	if err != nil {
 		return nil, err
 	}
 	return user, nil
 }
 
+// FindByID retrieves a user by their ID from the database.
+func (r *PostgresUserRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE id = $1`
+	return scanUser(r.DB.QueryRow(ctx, query, id))
+}
+
 // FindByEmail retrieves a user by their email from the database.
 func (r *PostgresUserRepository) FindByEmail(ctx context.Context, email string) (*entities.User, error) {
-	query := `SELECT id, name, email, password_hash, is_email_verified, created_at, updated_at, last_login_at, avatar_url, avatar_public_id, is_deleted, deleted_at, deletion_due_at, gender FROM users WHERE email = $1`
-	user := &entities.User{}
-	err := r.DB.QueryRow(ctx, query, email).Scan(&user.ID, &user.Name, &user.Email, &user.PasswordHash, &user.IsEmailVerified, &user.CreatedAt, &user.UpdatedAt, &user.LastLoginAt, &user.AvatarURL, &user.AvatarPublicID, &user.IsDeleted, &user.DeletedAt, &user.DeletionDueAt, &user.Gender)
-	if err != nil {
-		return nil, err
-	}
-	return user, nil
+	query := `SELECT ` + userColumns + ` FROM users WHERE email = $1`
+	return scanUser(r.DB.QueryRow(ctx, query, email))
+}
+
+// FindByHandle retrieves a user by their handle from the database, ignoring case.
+func (r *PostgresUserRepository) FindByHandle(ctx context.Context, handle string) (*entities.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE lower(handle) = lower($1)`
+	return scanUser(r.DB.QueryRow(ctx, query, handle))
 }
 
-// Update updates a user in the database.
+// Update updates a user in the database. The suspension and the handle are left alone; they are only written
+// by the moderation queue and HandleRedirectRepository.ChangeHandle, so a stale copy of the user cannot undo them.
 func (r *PostgresUserRepository) Update(ctx context.Context, user *entities.User) error {
-	query := `UPDATE users SET name = $1, email = $2, password_hash = $3, is_email_verified = $4, is_deleted = $5, deleted_at = $6, created_at = $7, updated_at = $8, last_login_at = $9, avatar_url = $10, deletion_due_at = $11 WHERE id = $12`
-	_, err := r.DB.Exec(ctx, query, user.Name, user.Email, user.PasswordHash, user.IsEmailVerified, user.IsDeleted, user.DeletedAt, user.CreatedAt, user.UpdatedAt, user.LastLoginAt, user.AvatarURL, user.DeletionDueAt, user.ID)
+	query := `UPDATE users SET name = $1, email = $2, password_hash = $3, is_email_verified = $4, is_deleted = $5, deleted_at = $6, created_at = $7, updated_at = $8, last_login_at = $9, avatar_url = $10, deletion_due_at = $11, dm_privacy = $12, discoverable = $13, bio = $14, status_text = $15, pronouns = $16, links = COALESCE($17, '{}'::text[]) WHERE id = $18`
+	_, err := r.DB.Exec(ctx, query, user.Name, user.Email, user.PasswordHash, user.IsEmailVerified, user.IsDeleted, user.DeletedAt, user.CreatedAt, user.UpdatedAt, user.LastLoginAt, user.AvatarURL, user.DeletionDueAt, user.DMPrivacy, user.Discoverable, user.Bio, user.StatusText, user.Pronouns, user.Links, user.ID)
+	return err
+}
+
+// UpdateLastLogin sets only the last login time, so a login cannot overwrite changes made while it ran.
+func (r *PostgresUserRepository) UpdateLastLogin(ctx context.Context, id uuid.UUID, at time.Time) error {
+	query := `UPDATE users SET last_login_at = $1, updated_at = $1 WHERE id = $2`
+	_, err := r.DB.Exec(ctx, query, at, id)
 	return err
 }
 
@@ -71,7 +97,7 @@ func (r *PostgresUserRepository) Delete(ctx context.Context, id uuid.UUID) error
 
 // FindSoftDeletedBefore retrieves all soft-deleted users from the database before a given time.
 func (r *PostgresUserRepository) FindSoftDeletedBefore(ctx context.Context, t time.Time) ([]*entities.User, error) {
-	query := `SELECT id, name, email, password_hash, is_email_verified, created_at, updated_at, last_login_at, avatar_url, is_deleted, deleted_at, deletion_due_at FROM users WHERE is_deleted = true AND deleted_at < $1`
+	query := `SELECT ` + userColumns + ` FROM users WHERE is_deleted = true AND deleted_at < $1`
 	rows, err := r.DB.Query(ctx, query, t)
 	if err != nil {
 		return nil, err
@@ -80,8 +106,7 @@ func (r *PostgresUserRepository) FindSoftDeletedBefore(ctx context.Context, t ti
 
 	var users []*entities.User
 	for rows.Next() {
-		user := &entities.User{}
-		err := rows.Scan(&user.ID, &user.Name, &user.Email, &user.PasswordHash, &user.IsEmailVerified, &user.CreatedAt, &user.UpdatedAt, &user.LastLoginAt, &user.AvatarURL, &user.IsDeleted, &user.DeletedAt, &user.DeletionDueAt)
+		user, err := scanUser(rows)
 		if err != nil {
 			return nil, err
 		}
@@ -98,28 +123,6 @@ func (r *PostgresUserRepository) HardDelete(ctx context.Context, id uuid.UUID) e
 	return err
 }
 
-// FindAll retrieves all users from the database.
-func (r *PostgresUserRepository) FindAll(ctx context.Context) ([]*entities.User, error) {
-	query := `SELECT id, name, email, password_hash, is_email_verified, created_at, updated_at, last_login_at, avatar_url, is_deleted, deleted_at, deletion_due_at FROM users`
-	rows, err := r.DB.Query(ctx, query)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var users []*entities.User
-	for rows.Next() {
-		user := &entities.User{}
-		err := rows.Scan(&user.ID, &user.Name, &user.Email, &user.PasswordHash, &user.IsEmailVerified, &user.CreatedAt, &user.UpdatedAt, &user.LastLoginAt, &user.AvatarURL, &user.IsDeleted, &user.DeletedAt, &user.DeletionDueAt)
-		if err != nil {
-			return nil, err
-		}
-		users = append(users, user)
-	}
-
-	return users, nil
-}
-
 // UpdateAvatar updates the avatar URL and public ID of a user in the database.
 func (r *PostgresUserRepository) UpdateAvatar(ctx context.Context, id uuid.UUID, avatarURL, avatarPublicID string) error {
 	query := `UPDATE users SET avatar_url = $1, avatar_public_id = $2, updated_at = $3 WHERE id = $4`
@@ -0,0 +1,69 @@
+package infrastructure
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jefersonprimer/chatear/backend/domain/entities"
+	"github.com/jefersonprimer/chatear/backend/domain/repositories"
+)
+
+// PostgresUserSearchRepository is a PostgreSQL implementation of the UserSearchRepository, using pg_trgm.
+type PostgresUserSearchRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewPostgresUserSearchRepository creates a new PostgresUserSearchRepository.
+func NewPostgresUserSearchRepository(db *pgxpool.Pool) repositories.UserSearchRepository {
+	return &PostgresUserSearchRepository{
+		db: db,
+	}
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// Search ranks names and handles that start with the query first, then by trigram similarity. Results are paged by
+// keyset on (prefix match, similarity, id) so that pages stay stable while users sign up.
+func (r *PostgresUserSearchRepository) Search(ctx context.Context, viewerID uuid.UUID, query string, limit int, after *entities.UserSearchCursor) ([]*entities.UserSearchResult, error) {
+	prefix := likeEscaper.Replace(strings.ToLower(query)) + "%"
+	args := []any{viewerID, query, prefix, limit}
+
+	sql := `SELECT id, name, handle, avatar_url, bio, status_text, pronouns, links, prefix_match, score FROM (
+			SELECT u.id, u.name, u.handle, u.avatar_url, u.bio, u.status_text, u.pronouns, u.links,
+				(lower(u.name) LIKE $3 OR lower(u.handle) LIKE $3) IS TRUE AS prefix_match,
+				GREATEST(similarity(u.name, $2), COALESCE(similarity(u.handle, $2), 0)) AS score
+			FROM users u
+			WHERE u.is_deleted = false
+				AND u.discoverable = true
+				AND u.id <> $1
+				AND (lower(u.name) LIKE $3 OR lower(u.handle) LIKE $3 OR u.name % $2 OR u.handle % $2)
+				AND NOT EXISTS (
+					SELECT 1 FROM user_blocks b
+					WHERE (b.blocker_id = u.id AND b.blocked_id = $1) OR (b.blocker_id = $1 AND b.blocked_id = u.id)
+				)
+		) matches`
+	if after != nil {
+		args = append(args, after.PrefixMatch, after.Score, after.UserID)
+		sql += ` WHERE (prefix_match, score, id) < ($5, $6, $7)`
+	}
+	sql += ` ORDER BY prefix_match DESC, score DESC, id DESC LIMIT $4`
+
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*entities.UserSearchResult
+	for rows.Next() {
+		result := &entities.UserSearchResult{Profile: &entities.PublicProfile{}}
+		profile := result.Profile
+		if err := rows.Scan(&profile.ID, &profile.Name, &profile.Handle, &profile.AvatarURL, &profile.Bio, &profile.StatusText, &profile.Pronouns, &profile.Links, &result.PrefixMatch, &result.Score); err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	return results, rows.Err()
+}
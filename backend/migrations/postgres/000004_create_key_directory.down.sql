@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS update_device_keys_updated_at ON public.device_keys;
+DROP INDEX IF EXISTS idx_one_time_prekeys_device_created;
+DROP TABLE IF EXISTS public.one_time_prekeys;
+DROP TABLE IF EXISTS public.device_keys;
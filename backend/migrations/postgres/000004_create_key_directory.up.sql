@@ -0,0 +1,29 @@
+-- End-to-end encryption key directory. Only public key material is stored.
+CREATE TABLE public.device_keys (
+  user_id uuid NOT NULL,
+  device_id integer NOT NULL,
+  identity_key text NOT NULL,
+  signed_prekey_id integer NOT NULL,
+  signed_prekey text NOT NULL,
+  signed_prekey_signature text NOT NULL,
+  created_at timestamp without time zone DEFAULT now(),
+  updated_at timestamp without time zone DEFAULT now(),
+  CONSTRAINT device_keys_pkey PRIMARY KEY (user_id, device_id),
+  CONSTRAINT device_keys_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE public.one_time_prekeys (
+  user_id uuid NOT NULL,
+  device_id integer NOT NULL,
+  key_id integer NOT NULL,
+  public_key text NOT NULL,
+  created_at timestamp without time zone DEFAULT now(),
+  CONSTRAINT one_time_prekeys_pkey PRIMARY KEY (user_id, device_id, key_id),
+  CONSTRAINT one_time_prekeys_device_fkey FOREIGN KEY (user_id, device_id) REFERENCES public.device_keys(user_id, device_id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_one_time_prekeys_device_created ON public.one_time_prekeys USING btree (user_id, device_id, created_at);
+
+CREATE TRIGGER update_device_keys_updated_at
+BEFORE UPDATE ON public.device_keys
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,9 @@
+DROP TRIGGER IF EXISTS update_reports_updated_at ON public.reports;
+DROP INDEX IF EXISTS idx_reports_reporter_target;
+DROP INDEX IF EXISTS idx_reports_claimed_by;
+DROP INDEX IF EXISTS idx_reports_status_created_at;
+DROP TABLE IF EXISTS public.reports;
+
+ALTER TABLE public.users
+  DROP COLUMN IF EXISTS suspended_until,
+  DROP COLUMN IF EXISTS role;
@@ -0,0 +1,36 @@
+-- Moderation: user roles, suspensions and abuse reports
+ALTER TABLE public.users
+  ADD COLUMN role text NOT NULL DEFAULT 'user'::text CHECK (role = ANY (ARRAY['user'::text, 'moderator'::text])),
+  ADD COLUMN suspended_until timestamp without time zone;
+
+CREATE TABLE public.reports (
+  id uuid NOT NULL DEFAULT gen_random_uuid(),
+  reporter_id uuid NOT NULL,
+  target_type text NOT NULL DEFAULT 'user'::text CHECK (target_type = ANY (ARRAY['user'::text])),
+  target_user_id uuid NOT NULL,
+  category text NOT NULL CHECK (category = ANY (ARRAY['spam'::text, 'harassment'::text, 'hate_speech'::text, 'impersonation'::text, 'inappropriate_content'::text, 'other'::text])),
+  details text,
+  snapshot jsonb NOT NULL,
+  status text NOT NULL DEFAULT 'open'::text CHECK (status = ANY (ARRAY['open'::text, 'claimed'::text, 'resolved'::text])),
+  claimed_by uuid,
+  claimed_at timestamp without time zone,
+  resolved_by uuid,
+  resolved_at timestamp without time zone,
+  action text CHECK (action = ANY (ARRAY['dismiss'::text, 'warn'::text, 'suspend'::text])),
+  resolution_note text,
+  created_at timestamp without time zone DEFAULT now(),
+  updated_at timestamp without time zone DEFAULT now(),
+  CONSTRAINT reports_pkey PRIMARY KEY (id),
+  CONSTRAINT reports_reporter_id_fkey FOREIGN KEY (reporter_id) REFERENCES public.users(id) ON DELETE CASCADE,
+  CONSTRAINT reports_target_user_id_fkey FOREIGN KEY (target_user_id) REFERENCES public.users(id) ON DELETE CASCADE,
+  CONSTRAINT reports_claimed_by_fkey FOREIGN KEY (claimed_by) REFERENCES public.users(id) ON DELETE SET NULL,
+  CONSTRAINT reports_resolved_by_fkey FOREIGN KEY (resolved_by) REFERENCES public.users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_reports_status_created_at ON public.reports USING btree (status, created_at);
+CREATE INDEX idx_reports_claimed_by ON public.reports USING btree (claimed_by) WHERE (claimed_by IS NOT NULL);
+CREATE INDEX idx_reports_reporter_target ON public.reports USING btree (reporter_id, target_user_id);
+
+CREATE TRIGGER update_reports_updated_at
+BEFORE UPDATE ON public.reports
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
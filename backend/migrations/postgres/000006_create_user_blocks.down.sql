@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_user_blocks_blocked_id;
+DROP TABLE IF EXISTS public.user_blocks;
@@ -0,0 +1,12 @@
+CREATE TABLE public.user_blocks (
+  blocker_id uuid NOT NULL,
+  blocked_id uuid NOT NULL,
+  created_at timestamp without time zone DEFAULT now(),
+  CONSTRAINT user_blocks_pkey PRIMARY KEY (blocker_id, blocked_id),
+  CONSTRAINT user_blocks_not_self CHECK (blocker_id <> blocked_id),
+  CONSTRAINT user_blocks_blocker_id_fkey FOREIGN KEY (blocker_id) REFERENCES public.users(id) ON DELETE CASCADE,
+  CONSTRAINT user_blocks_blocked_id_fkey FOREIGN KEY (blocked_id) REFERENCES public.users(id) ON DELETE CASCADE
+);
+
+-- Lookups from the blocked side ("has anyone blocked me?")
+CREATE INDEX idx_user_blocks_blocked_id ON public.user_blocks USING btree (blocked_id);
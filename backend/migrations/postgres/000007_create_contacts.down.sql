@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS public.contacts;
+DROP INDEX IF EXISTS idx_contact_requests_sender_recipient_closed;
+DROP INDEX IF EXISTS idx_contact_requests_sender_pending;
+DROP INDEX IF EXISTS idx_contact_requests_recipient_pending;
+DROP INDEX IF EXISTS idx_contact_requests_pending_pair;
+DROP TABLE IF EXISTS public.contact_requests;
+
+ALTER TABLE public.users
+  DROP COLUMN IF EXISTS dm_privacy;
@@ -0,0 +1,33 @@
+-- Contacts, contact requests and the direct message privacy setting
+ALTER TABLE public.users
+  ADD COLUMN dm_privacy text NOT NULL DEFAULT 'everyone'::text CHECK (dm_privacy = ANY (ARRAY['everyone'::text, 'contacts'::text]));
+
+CREATE TABLE public.contact_requests (
+  id uuid NOT NULL DEFAULT gen_random_uuid(),
+  sender_id uuid NOT NULL,
+  recipient_id uuid NOT NULL,
+  status text NOT NULL DEFAULT 'pending'::text CHECK (status = ANY (ARRAY['pending'::text, 'accepted'::text, 'declined'::text, 'cancelled'::text])),
+  created_at timestamp without time zone DEFAULT now(),
+  responded_at timestamp without time zone,
+  CONSTRAINT contact_requests_pkey PRIMARY KEY (id),
+  CONSTRAINT contact_requests_not_self CHECK (sender_id <> recipient_id),
+  CONSTRAINT contact_requests_sender_id_fkey FOREIGN KEY (sender_id) REFERENCES public.users(id) ON DELETE CASCADE,
+  CONSTRAINT contact_requests_recipient_id_fkey FOREIGN KEY (recipient_id) REFERENCES public.users(id) ON DELETE CASCADE
+);
+
+-- At most one pending request per pair of users, whichever way it was sent
+CREATE UNIQUE INDEX idx_contact_requests_pending_pair ON public.contact_requests USING btree (LEAST(sender_id, recipient_id), GREATEST(sender_id, recipient_id)) WHERE (status = 'pending'::text);
+CREATE INDEX idx_contact_requests_recipient_pending ON public.contact_requests USING btree (recipient_id, created_at) WHERE (status = 'pending'::text);
+CREATE INDEX idx_contact_requests_sender_pending ON public.contact_requests USING btree (sender_id, created_at) WHERE (status = 'pending'::text);
+-- Finds recently cancelled or declined requests for the re-send cooldown
+CREATE INDEX idx_contact_requests_sender_recipient_closed ON public.contact_requests USING btree (sender_id, recipient_id, responded_at) WHERE (status = ANY (ARRAY['declined'::text, 'cancelled'::text]));
+
+CREATE TABLE public.contacts (
+  user_id uuid NOT NULL,
+  contact_id uuid NOT NULL,
+  created_at timestamp without time zone DEFAULT now(),
+  CONSTRAINT contacts_pkey PRIMARY KEY (user_id, contact_id),
+  CONSTRAINT contacts_not_self CHECK (user_id <> contact_id),
+  CONSTRAINT contacts_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE,
+  CONSTRAINT contacts_contact_id_fkey FOREIGN KEY (contact_id) REFERENCES public.users(id) ON DELETE CASCADE
+);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_users_name_lower;
+DROP INDEX IF EXISTS idx_users_name_trgm;
+
+ALTER TABLE public.users
+  DROP COLUMN IF EXISTS discoverable;
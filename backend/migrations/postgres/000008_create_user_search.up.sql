@@ -0,0 +1,8 @@
+-- User search: trigram index on names and an opt-out from search results
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE public.users
+  ADD COLUMN discoverable boolean NOT NULL DEFAULT true;
+
+CREATE INDEX idx_users_name_trgm ON public.users USING gin (name gin_trgm_ops);
+CREATE INDEX idx_users_name_lower ON public.users USING btree (lower(name) text_pattern_ops);
@@ -0,0 +1,14 @@
+DROP INDEX IF EXISTS idx_handle_redirects_handle_lower;
+DROP TABLE IF EXISTS public.handle_redirects;
+
+DROP INDEX IF EXISTS idx_users_handle_lower_pattern;
+DROP INDEX IF EXISTS idx_users_handle_trgm;
+DROP INDEX IF EXISTS idx_users_handle_lower;
+
+ALTER TABLE public.users
+  DROP COLUMN IF EXISTS links,
+  DROP COLUMN IF EXISTS pronouns,
+  DROP COLUMN IF EXISTS status_text,
+  DROP COLUMN IF EXISTS bio,
+  DROP COLUMN IF EXISTS handle_changed_at,
+  DROP COLUMN IF EXISTS handle;
@@ -0,0 +1,25 @@
+-- Handles and public profile fields
+ALTER TABLE public.users
+  ADD COLUMN handle text,
+  ADD COLUMN handle_changed_at timestamp without time zone,
+  ADD COLUMN bio text,
+  ADD COLUMN status_text text,
+  ADD COLUMN pronouns text,
+  ADD COLUMN links text[] NOT NULL DEFAULT '{}'::text[];
+
+-- Handles are unique regardless of case
+CREATE UNIQUE INDEX idx_users_handle_lower ON public.users USING btree (lower(handle)) WHERE (handle IS NOT NULL);
+CREATE INDEX idx_users_handle_trgm ON public.users USING gin (handle gin_trgm_ops);
+-- Serves prefix searches on lower(handle); the unique index above cannot serve LIKE under a non-C collation
+CREATE INDEX idx_users_handle_lower_pattern ON public.users USING btree (lower(handle) text_pattern_ops);
+
+-- Released handles keep pointing at their previous owner until expires_at
+CREATE TABLE public.handle_redirects (
+  handle text NOT NULL,
+  user_id uuid NOT NULL,
+  created_at timestamp without time zone DEFAULT now(),
+  expires_at timestamp without time zone NOT NULL,
+  CONSTRAINT handle_redirects_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX idx_handle_redirects_handle_lower ON public.handle_redirects USING btree (lower(handle));
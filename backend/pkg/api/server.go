@@ -13,6 +13,7 @@ import (
 	"github.com/jefersonprimer/chatear/backend/graph"
 	"github.com/jefersonprimer/chatear/backend/infrastructure"
 	"github.com/jefersonprimer/chatear/backend/application/usecases"
+	notificationInfra "github.com/jefersonprimer/chatear/backend/internal/notification/infrastructure"
 	userApp "github.com/jefersonprimer/chatear/backend/internal/user/application"
 	userInfra "github.com/jefersonprimer/chatear/backend/internal/user/infrastructure"
 	userSvc "github.com/jefersonprimer/chatear/backend/internal/user/services"
@@ -21,6 +22,7 @@ import (
 	"github.com/jefersonprimer/chatear/backend/presentation/http"
 	"github.com/jefersonprimer/chatear/backend/presentation/middleware"
 	"github.com/jefersonprimer/chatear/backend/shared/auth"
+	appErrors "github.com/jefersonprimer/chatear/backend/shared/errors"
 )
 
 func SetupServer(cfg *config.Config) (*gin.Engine, error) {
@@ -36,6 +38,12 @@ func SetupServer(cfg *config.Config) (*gin.Engine, error) {
 	refreshTokenRepo := userInfra.NewPostgresRefreshTokenRepository(infra.DB)
 	emailLimiter := userInfra.NewRedisEmailLimiter(infra.Redis, cfg)
 	userDeletionRepo := userInfra.NewPostgresUserDeletionRepository(infra.DB)
+	deviceKeysRepo := userInfra.NewPostgresDeviceKeysRepository(infra.DB)
+	reportRepo := userInfra.NewPostgresReportRepository(infra.DB)
+	userBlockRepo := userInfra.NewPostgresUserBlockRepository(infra.DB)
+	contactRepo := userInfra.NewPostgresContactRepository(infra.DB)
+	userSearchRepo := userInfra.NewPostgresUserSearchRepository(infra.DB)
+	handleRedirectRepo := userInfra.NewPostgresHandleRedirectRepository(infra.DB)
 	
 
 	// Initialize event bus (NATS for example)
@@ -57,13 +65,19 @@ func SetupServer(cfg *config.Config) (*gin.Engine, error) {
 		deleteUser := userApp.NewDeleteUser(userRepo, oneTimeTokenService, eventBus, userDeletionRepo, cfg.FrontendURL)
 		recoverAccount := userApp.NewRecoverAccount(userRepo, nil, oneTimeTokenService)
 		refreshToken := userApp.NewRefreshToken(refreshTokenRepo, tokenService, userRepo)
-		getUsersUseCase := usecases.NewUserUseCases(userRepo)
 		verifyTokenAndResetPasswordUseCase := userApp.NewVerifyTokenAndResetPassword(userRepo, oneTimeTokenService)
 		cloudinaryService, err := userSvc.NewCloudinaryService(cfg.CloudinaryURL)
 		if err != nil {
 			return nil, err
 		}
 		avatarUsecases := usecases.NewAvatarUsecases(userRepo, cloudinaryService)
+		keyDirectory := userApp.NewKeyDirectory(deviceKeysRepo, userBlockRepo, eventBus, notificationInfra.NewRedisRateLimiterWithLimit(infra.Redis, cfg, cfg.MaxPrekeyClaimsPerDay))
+		moderation := userApp.NewModeration(reportRepo, userRepo, eventBus, val)
+		blocking := userApp.NewBlocking(userBlockRepo, contactRepo, userRepo)
+		contacts := userApp.NewContacts(contactRepo, userBlockRepo, userRepo, eventBus)
+		privacySettings := userApp.NewPrivacySettings(userRepo, val)
+		userSearch := userApp.NewUserSearch(userSearchRepo)
+		profiles := userApp.NewProfiles(userRepo, handleRedirectRepo, userBlockRepo, val)
 	
 			
 		// Initialize HTTP handlers
@@ -117,21 +131,35 @@ func SetupServer(cfg *config.Config) (*gin.Engine, error) {
 					DeleteUser:          deleteUser,
 					RecoverAccount:      recoverAccount,
 					RefreshToken:        refreshToken,
-					GetUsersUseCase:     getUsersUseCase,
 					TokenService:        tokenService,
 					OneTimeTokenService: oneTimeTokenService,
 					EmailRateLimiter:    emailLimiter,
 					EventBus:            eventBus,
 					UserRepository:      userRepo,
 					AvatarUsecases:      avatarUsecases,
+					KeyDirectory:        keyDirectory,
+					Moderation:          moderation,
+					Blocking:            blocking,
+					Contacts:            contacts,
+					PrivacySettings:     privacySettings,
+					UserSearch:          userSearch,
+					Profiles:            profiles,
 				},
 			}
 		
 			c.Directives.IsAuthenticated = func(ctx context.Context, obj interface{}, next graphql.Resolver) (res interface{}, err error) {
-				_, err = auth.GetUserIDFromContext(ctx)
+				userID, err := auth.GetUserIDFromContext(ctx)
 				if err != nil {
 					return nil, errors.New("Access denied: User not authenticated.")
 				}
+				// Access tokens outlive a suspension by up to their TTL, so check the account on every request
+				user, err := userRepo.FindByID(ctx, userID)
+				if err != nil {
+					return nil, errors.New("Access denied: User not authenticated.")
+				}
+				if user.IsSuspended() {
+					return nil, appErrors.ErrAccountSuspended
+				}
 				return next(ctx)
 			}
 		
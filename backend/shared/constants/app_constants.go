@@ -8,3 +8,30 @@ const (
 	AccessTokenExpiration  = 15 * time.Minute
 	RefreshTokenExpiration = 7 * 24 * time.Hour // 7 days
 )
+
+const (
+	MaxOneTimePrekeysPerUpload = 100
+	PrekeyLowThreshold         = 10
+)
+
+const (
+	DefaultSuspensionDays       = 7
+	MaxSuspensionDays           = 365
+	DefaultModerationQueueLimit = 50
+	MaxModerationQueueLimit     = 200
+)
+
+const (
+	ContactRequestCooldown = 24 * time.Hour
+)
+
+const (
+	DefaultUserSearchLimit = 20
+	MaxUserSearchLimit     = 50
+)
+
+const (
+	HandleChangeCooldown      = 30 * 24 * time.Hour
+	HandleRedirectGracePeriod = 14 * 24 * time.Hour
+	MaxProfileLinks           = 5
+)
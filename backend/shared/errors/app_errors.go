@@ -13,4 +13,26 @@ var (
 	ErrInvalidToken         = errors.New("invalid token")
 	ErrTokenExpired         = errors.New("token expired")
 	ErrUserNotFound         = errors.New("user not found")
+	ErrInvalidKeyMaterial   = errors.New("invalid key material")
+	ErrTooManyPrekeys       = errors.New("too many prekeys in a single upload")
+	ErrForbidden            = errors.New("forbidden")
+	ErrAccountSuspended     = errors.New("account suspended")
+	ErrReportAlreadyFiled   = errors.New("you already have a pending report for this user")
+	ErrCannotReportSelf     = errors.New("you cannot report yourself")
+	ErrReportNotClaimable   = errors.New("report is no longer open")
+	ErrReportNotClaimed     = errors.New("report must be claimed by you before it can be resolved")
+	ErrCannotModerateSelf   = errors.New("you cannot moderate a report against yourself")
+	ErrCannotBlockSelf      = errors.New("you cannot block yourself")
+	ErrCannotContactSelf    = errors.New("you cannot add yourself as a contact")
+	ErrAlreadyContacts      = errors.New("you are already contacts")
+	ErrContactRequestExists = errors.New("you already sent a contact request to this user")
+	ErrContactRequestClosed = errors.New("contact request is no longer pending")
+	ErrContactResendTooSoon = errors.New("you sent this user a contact request recently, please try again later")
+	ErrUserBlockedByYou     = errors.New("unblock this user first")
+	ErrInvalidHandle        = errors.New("handles must be 3 to 30 letters, digits or underscores and start with a letter")
+	ErrHandleReserved       = errors.New("this handle is reserved")
+	ErrHandleTaken          = errors.New("this handle is already taken")
+	ErrHandleChangeTooSoon  = errors.New("you changed your handle recently, please try again later")
+	ErrInvalidCursor        = errors.New("invalid cursor")
+	ErrDirectMessagesClosed = errors.New("this user only accepts direct messages from contacts")
 )
@@ -0,0 +1,15 @@
+package events
+
+import "time"
+
+const ContactRequestReceivedSubject = "contacts.request.received"
+
+// ContactRequestReceivedEvent is published when a user receives a contact request, so they can be told
+type ContactRequestReceivedEvent struct {
+	RequestID  string    `json:"requestID"`
+	UserID     string    `json:"userID"`
+	Email      string    `json:"email"`
+	Name       string    `json:"name"`
+	SenderName string    `json:"senderName"`
+	Timestamp  time.Time `json:"timestamp"`
+}
@@ -0,0 +1,24 @@
+package events
+
+import "time"
+
+const (
+	PrekeysLowSubject         = "keys.prekeys.low"
+	IdentityKeyChangedSubject = "keys.identity.changed"
+)
+
+// PrekeysLowEvent is published when a device is running out of one-time prekeys
+type PrekeysLowEvent struct {
+	UserID    string    `json:"userID"`
+	DeviceID  int       `json:"deviceID"`
+	Remaining int       `json:"remaining"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// IdentityKeyChangedEvent is published when a device replaces its identity key,
+// so peers can warn that the safety number changed
+type IdentityKeyChangedEvent struct {
+	UserID    string    `json:"userID"`
+	DeviceID  int       `json:"deviceID"`
+	Timestamp time.Time `json:"timestamp"`
+}
@@ -0,0 +1,39 @@
+package events
+
+import "time"
+
+const (
+	ReportResolvedSubject = "moderation.report.resolved"
+	UserWarnedSubject     = "moderation.user.warned"
+	UserSuspendedSubject  = "moderation.user.suspended"
+)
+
+// ReportResolvedEvent is published when a moderator closes a report, so the reporter can be told
+type ReportResolvedEvent struct {
+	ReportID  string    `json:"reportID"`
+	UserID    string    `json:"userID"`
+	Email     string    `json:"email"`
+	Name      string    `json:"name"`
+	Action    string    `json:"action"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// UserWarnedEvent is published when a moderator warns a reported user
+type UserWarnedEvent struct {
+	UserID    string    `json:"userID"`
+	Email     string    `json:"email"`
+	Name      string    `json:"name"`
+	Category  string    `json:"category"`
+	Note      string    `json:"note"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// UserSuspendedEvent is published when a moderator suspends a reported user
+type UserSuspendedEvent struct {
+	UserID    string    `json:"userID"`
+	Email     string    `json:"email"`
+	Name      string    `json:"name"`
+	Until     time.Time `json:"until"`
+	Note      string    `json:"note"`
+	Timestamp time.Time `json:"timestamp"`
+}
@@ -1,11 +0,0 @@
-import { gql } from '@apollo/client';
-
-export const GET_USERS = gql`
-  query GetUsers {
-    users {
-      id
-      name
-      email
-    }
-  }
-`;